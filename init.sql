@@ -6,7 +6,9 @@ CREATE TABLE IF NOT EXISTS banners (
     content JSON NOT NULL,
     created_at TIMESTAMP WITHOUT TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
     updated_at TIMESTAMP WITHOUT TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
-    is_active BOOLEAN NOT NULL
+    is_active BOOLEAN NOT NULL,
+    active_from TIMESTAMP WITH TIME ZONE,
+    active_until TIMESTAMP WITH TIME ZONE
 );
 
 CREATE TABLE IF NOT EXISTS banner_feature_tags (
@@ -0,0 +1,94 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+const maxCachedTokens = 10000
+
+// CachedStore remembers lookup results of the wrapped store for a TTL so that
+// authenticating a request does not cost a database round trip. Unknown,
+// expired and revoked tokens are cached as well; errors of the underlying
+// store are not.
+type CachedStore struct {
+	store TokenStore
+	ttl   time.Duration
+
+	mu      sync.Mutex
+	entries map[string]cacheEntry
+}
+
+type cacheEntry struct {
+	token     *Token
+	err       error
+	expiresAt time.Time
+}
+
+func NewCachedStore(store TokenStore, ttl time.Duration) *CachedStore {
+	return &CachedStore{
+		store:   store,
+		ttl:     ttl,
+		entries: make(map[string]cacheEntry),
+	}
+}
+
+func (c *CachedStore) Lookup(ctx context.Context, token string) (*Token, error) {
+	hash := HashToken(token)
+	now := time.Now()
+
+	c.mu.Lock()
+	entry, ok := c.entries[hash]
+	c.mu.Unlock()
+	if ok && now.Before(entry.expiresAt) {
+		return entry.token, entry.err
+	}
+
+	t, err := c.store.Lookup(ctx, token)
+	if err != nil && !IsTokenError(err) {
+		return nil, err
+	}
+
+	expiresAt := now.Add(c.ttl)
+	if t != nil && t.ExpiresAt != nil && t.ExpiresAt.Before(expiresAt) {
+		expiresAt = *t.ExpiresAt
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if len(c.entries) >= maxCachedTokens {
+		c.evictExpired(now)
+	}
+	if len(c.entries) < maxCachedTokens {
+		c.entries[hash] = cacheEntry{token: t, err: err, expiresAt: expiresAt}
+	}
+	return t, err
+}
+
+// Forget drops the cached result of the token with the given ID, e.g. after
+// it has been revoked. Entries are keyed by token hash, so it scans them.
+func (c *CachedStore) Forget(id string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for hash, entry := range c.entries {
+		if entry.token != nil && entry.token.ID == id {
+			delete(c.entries, hash)
+		}
+	}
+}
+
+func (c *CachedStore) evictExpired(now time.Time) {
+	for hash, entry := range c.entries {
+		if !now.Before(entry.expiresAt) {
+			delete(c.entries, hash)
+		}
+	}
+}
+
+// IsTokenError reports whether err means the token itself was rejected, as
+// opposed to the store failing to answer.
+func IsTokenError(err error) bool {
+	return errors.Is(err, ErrTokenNotFound) || errors.Is(err, ErrTokenExpired) || errors.Is(err, ErrTokenRevoked)
+}
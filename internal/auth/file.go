@@ -0,0 +1,78 @@
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+)
+
+// StaticStore serves a fixed set of tokens loaded from a config file.
+type StaticStore struct {
+	tokens map[string]Token
+}
+
+type staticFile struct {
+	Tokens []staticEntry `json:"tokens"`
+}
+
+// staticEntry is a token as written in the config file. Either the raw
+// token or its SHA-256 hex digest may be given.
+type staticEntry struct {
+	ID          string     `json:"id"`
+	Token       string     `json:"token"`
+	TokenSHA256 string     `json:"token_sha256"`
+	Role        string     `json:"role"`
+	ExpiresAt   *time.Time `json:"expires_at"`
+}
+
+func LoadStaticStore(path string) (*StaticStore, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read tokens file: %v", err)
+	}
+
+	var file staticFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("failed to parse tokens file: %v", err)
+	}
+
+	store := &StaticStore{tokens: make(map[string]Token, len(file.Tokens))}
+	for i, entry := range file.Tokens {
+		if !ValidRole(entry.Role) {
+			return nil, fmt.Errorf("tokens file entry %d: unknown role %q", i, entry.Role)
+		}
+
+		hash := entry.TokenSHA256
+		if entry.Token != "" {
+			hash = HashToken(entry.Token)
+		}
+		if hash == "" {
+			return nil, fmt.Errorf("tokens file entry %d: token or token_sha256 is required", i)
+		}
+
+		id := entry.ID
+		if id == "" {
+			id = fmt.Sprintf("static-%d", i)
+		}
+
+		store.tokens[hash] = Token{
+			ID:        id,
+			Role:      entry.Role,
+			ExpiresAt: entry.ExpiresAt,
+		}
+	}
+	return store, nil
+}
+
+func (s *StaticStore) Lookup(_ context.Context, token string) (*Token, error) {
+	t, ok := s.tokens[HashToken(token)]
+	if !ok {
+		return nil, ErrTokenNotFound
+	}
+	if err := t.Valid(time.Now()); err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
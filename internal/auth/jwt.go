@@ -0,0 +1,110 @@
+package auth
+
+import (
+	"bytes"
+	"crypto/rsa"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+var ErrInvalidJWT = errors.New("invalid jwt")
+
+// Claims are the JWT claims understood by the service. Role must be either
+// admin or user; TagID, when present, restricts a user to a single tag.
+type Claims struct {
+	jwt.RegisteredClaims
+	Role  string `json:"role"`
+	TagID *int   `json:"tag_id,omitempty"`
+}
+
+// JWTVerifier checks bearer tokens signed with HS256 and/or RS256.
+type JWTVerifier struct {
+	hmacSecret []byte
+	rsaKey     *rsa.PublicKey
+	methods    []string
+}
+
+// NewJWTVerifier builds a verifier accepting HS256 tokens when hmacSecret is
+// set and RS256 tokens when rsaKey is set. At least one key is required.
+func NewJWTVerifier(hmacSecret []byte, rsaKey *rsa.PublicKey) (*JWTVerifier, error) {
+	v := &JWTVerifier{hmacSecret: hmacSecret, rsaKey: rsaKey}
+	if len(hmacSecret) > 0 {
+		v.methods = append(v.methods, jwt.SigningMethodHS256.Alg())
+	}
+	if rsaKey != nil {
+		v.methods = append(v.methods, jwt.SigningMethodRS256.Alg())
+	}
+	if len(v.methods) == 0 {
+		return nil, errors.New("jwt verifier needs an HS256 secret or an RS256 public key")
+	}
+	return v, nil
+}
+
+// ParseRSAPublicKey decodes a PEM encoded RSA public key.
+func ParseRSAPublicKey(pem []byte) (*rsa.PublicKey, error) {
+	return jwt.ParseRSAPublicKeyFromPEM(pem)
+}
+
+// ReadKey returns value if it is set and the contents of file otherwise, so
+// keys can be passed either inline through the environment or as mounted
+// files. Both empty yields nil. Surrounding whitespace is trimmed, so the
+// trailing newline of a secret file is not taken as part of the secret.
+func ReadKey(value, file string) ([]byte, error) {
+	if value != "" {
+		return bytes.TrimSpace([]byte(value)), nil
+	}
+	if file == "" {
+		return nil, nil
+	}
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read key file: %v", err)
+	}
+	return bytes.TrimSpace(data), nil
+}
+
+// Verify validates the signature and standard claims of a bearer token and
+// returns the Token it grants. Tokens must carry exp and sub: the subject
+// identifies the caller in rate limits and the audit log.
+func (v *JWTVerifier) Verify(raw string) (*Token, error) {
+	var claims Claims
+	parsed, err := jwt.ParseWithClaims(raw, &claims, v.key, jwt.WithValidMethods(v.methods), jwt.WithExpirationRequired())
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidJWT, err)
+	}
+	if !parsed.Valid {
+		return nil, ErrInvalidJWT
+	}
+	if claims.Subject == "" {
+		return nil, fmt.Errorf("%w: missing subject", ErrInvalidJWT)
+	}
+	if !ValidRole(claims.Role) {
+		return nil, fmt.Errorf("%w: unknown role %q", ErrInvalidJWT, claims.Role)
+	}
+
+	token := &Token{
+		ID:    "jwt:" + claims.Subject,
+		Role:  claims.Role,
+		TagID: claims.TagID,
+	}
+	if claims.ExpiresAt != nil {
+		token.ExpiresAt = &claims.ExpiresAt.Time
+	}
+	if claims.IssuedAt != nil {
+		token.CreatedAt = claims.IssuedAt.Time
+	}
+	return token, nil
+}
+
+func (v *JWTVerifier) key(t *jwt.Token) (interface{}, error) {
+	switch t.Method.Alg() {
+	case jwt.SigningMethodHS256.Alg():
+		return v.hmacSecret, nil
+	case jwt.SigningMethodRS256.Alg():
+		return v.rsaKey, nil
+	}
+	return nil, fmt.Errorf("unexpected signing method %q", t.Method.Alg())
+}
@@ -0,0 +1,94 @@
+package auth
+
+import (
+	"avito/internal/db"
+	"context"
+	"errors"
+	"strconv"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// PostgresStore keeps hashed tokens in the tokens table.
+type PostgresStore struct {
+	db *gorm.DB
+}
+
+func NewPostgresStore(database *gorm.DB) *PostgresStore {
+	return &PostgresStore{db: database}
+}
+
+func (s *PostgresStore) Lookup(ctx context.Context, token string) (*Token, error) {
+	var row db.Token
+	if err := s.db.WithContext(ctx).Where("token_hash = ?", HashToken(token)).First(&row).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrTokenNotFound
+		}
+		return nil, err
+	}
+
+	t := fromRow(row)
+	if err := t.Valid(time.Now()); err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
+
+func (s *PostgresStore) Mint(ctx context.Context, role string, expiresAt *time.Time) (string, *Token, error) {
+	raw, err := generateToken()
+	if err != nil {
+		return "", nil, err
+	}
+
+	row := db.Token{
+		TokenHash: HashToken(raw),
+		Role:      role,
+		ExpiresAt: expiresAt,
+	}
+	if err := s.db.WithContext(ctx).Create(&row).Error; err != nil {
+		return "", nil, err
+	}
+
+	t := fromRow(row)
+	return raw, &t, nil
+}
+
+func (s *PostgresStore) List(ctx context.Context) ([]Token, error) {
+	var rows []db.Token
+	if err := s.db.WithContext(ctx).Order("id").Find(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	tokens := make([]Token, len(rows))
+	for i, row := range rows {
+		tokens[i] = fromRow(row)
+	}
+	return tokens, nil
+}
+
+func (s *PostgresStore) Revoke(ctx context.Context, id string) error {
+	rowID, err := strconv.ParseUint(id, 10, 64)
+	if err != nil {
+		return ErrTokenNotFound
+	}
+
+	result := s.db.WithContext(ctx).Model(&db.Token{}).Where("id = ?", rowID).Update("revoked", true)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrTokenNotFound
+	}
+	return nil
+}
+
+func fromRow(row db.Token) Token {
+	return Token{
+		ID:        strconv.FormatUint(uint64(row.ID), 10),
+		Role:      row.Role,
+		ExpiresAt: row.ExpiresAt,
+		Revoked:   row.Revoked,
+		CreatedAt: row.CreatedAt,
+	}
+}
@@ -0,0 +1,97 @@
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"time"
+)
+
+const (
+	RoleAdmin = "admin"
+	RoleUser  = "user"
+)
+
+var (
+	ErrTokenNotFound = errors.New("token not found")
+	ErrTokenExpired  = errors.New("token expired")
+	ErrTokenRevoked  = errors.New("token revoked")
+)
+
+// Token describes a credential known to a TokenStore. The raw token value is
+// never kept, only its identifier and the attributes needed for authorization.
+// TagID is set when the credential only allows access to a single tag.
+type Token struct {
+	ID        string
+	Role      string
+	TagID     *int
+	ExpiresAt *time.Time
+	Revoked   bool
+	CreatedAt time.Time
+}
+
+// Valid reports why the token cannot be used at the given moment, if at all.
+func (t *Token) Valid(now time.Time) error {
+	if t.Revoked {
+		return ErrTokenRevoked
+	}
+	if t.ExpiresAt != nil && !now.Before(*t.ExpiresAt) {
+		return ErrTokenExpired
+	}
+	return nil
+}
+
+// TokenStore resolves raw tokens presented by clients. Lookup returns
+// ErrTokenNotFound for unknown tokens and ErrTokenExpired or ErrTokenRevoked
+// for tokens that exist but can no longer be used.
+type TokenStore interface {
+	Lookup(ctx context.Context, token string) (*Token, error)
+}
+
+// TokenManager is a TokenStore that can also issue and revoke tokens.
+type TokenManager interface {
+	TokenStore
+	Mint(ctx context.Context, role string, expiresAt *time.Time) (string, *Token, error)
+	List(ctx context.Context) ([]Token, error)
+	Revoke(ctx context.Context, id string) error
+}
+
+func ValidRole(role string) bool {
+	return role == RoleAdmin || role == RoleUser
+}
+
+// HashToken returns the hex encoded SHA-256 digest under which a token is
+// stored.
+func HashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+func generateToken() (string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(buf), nil
+}
+
+type multiStore []TokenStore
+
+// NewMultiStore returns a TokenStore that asks each store in turn and
+// returns the first answer other than ErrTokenNotFound.
+func NewMultiStore(stores ...TokenStore) TokenStore {
+	return multiStore(stores)
+}
+
+func (m multiStore) Lookup(ctx context.Context, token string) (*Token, error) {
+	for _, store := range m {
+		t, err := store.Lookup(ctx, token)
+		if errors.Is(err, ErrTokenNotFound) {
+			continue
+		}
+		return t, err
+	}
+	return nil, ErrTokenNotFound
+}
@@ -0,0 +1,381 @@
+// Package config loads the service configuration from an optional YAML or
+// JSON file and environment variables, which take precedence over the file.
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+type Config struct {
+	HTTP      HTTPConfig      `yaml:"http"`
+	Database  DatabaseConfig  `yaml:"database"`
+	Redis     RedisConfig     `yaml:"redis"`
+	Cache     CacheConfig     `yaml:"cache"`
+	Trash     TrashConfig     `yaml:"trash"`
+	Log       LogConfig       `yaml:"log"`
+	Tracing   TracingConfig   `yaml:"tracing"`
+	RateLimit RateLimitConfig `yaml:"rate_limit"`
+	Auth      AuthConfig      `yaml:"auth"`
+}
+
+type HTTPConfig struct {
+	ListenAddr      string        `yaml:"listen_addr"`
+	ReadTimeout     time.Duration `yaml:"read_timeout"`
+	WriteTimeout    time.Duration `yaml:"write_timeout"`
+	IdleTimeout     time.Duration `yaml:"idle_timeout"`
+	ShutdownTimeout time.Duration `yaml:"shutdown_timeout"`
+}
+
+type DatabaseConfig struct {
+	URL             string        `yaml:"url"`
+	MaxOpenConns    int           `yaml:"max_open_conns"`
+	MaxIdleConns    int           `yaml:"max_idle_conns"`
+	ConnMaxLifetime time.Duration `yaml:"conn_max_lifetime"`
+	ConnMaxIdleTime time.Duration `yaml:"conn_max_idle_time"`
+}
+
+type RedisConfig struct {
+	Addr     string `yaml:"addr"`
+	DB       int    `yaml:"db"`
+	Password string `yaml:"password"`
+	TLS      bool   `yaml:"tls"`
+	// TLSServerName overrides the host name checked against the server
+	// certificate, which is taken from Addr by default.
+	TLSServerName string `yaml:"tls_server_name"`
+}
+
+type CacheConfig struct {
+	// BannerTTL is the lifetime of banner entries in Redis.
+	BannerTTL time.Duration `yaml:"banner_ttl"`
+	// LocalSize and LocalTTL size the in-process cache in front of Redis;
+	// zero disables it.
+	LocalSize int           `yaml:"local_size"`
+	LocalTTL  time.Duration `yaml:"local_ttl"`
+	// TokenTTL is how long token lookups are remembered.
+	TokenTTL time.Duration `yaml:"token_ttl"`
+}
+
+type TrashConfig struct {
+	// Retention is how long deleted banners stay restorable before the purge
+	// removes them for good; zero disables the purge.
+	Retention     time.Duration `yaml:"retention"`
+	PurgeInterval time.Duration `yaml:"purge_interval"`
+}
+
+type LogConfig struct {
+	Format string `yaml:"format"`
+	Level  string `yaml:"level"`
+}
+
+type TracingConfig struct {
+	// Exporter is none, stdout or otlp.
+	Exporter string `yaml:"exporter"`
+	// Endpoint is the OTLP/HTTP collector URL. When empty the exporter
+	// falls back to the standard OTEL_EXPORTER_OTLP_* variables.
+	Endpoint    string  `yaml:"endpoint"`
+	SampleRatio float64 `yaml:"sample_ratio"`
+	ServiceName string  `yaml:"service_name"`
+}
+
+type RateLimitConfig struct {
+	// ByIP gives every client address of a token its own bucket.
+	ByIP  bool      `yaml:"by_ip"`
+	User  RateLimit `yaml:"user"`
+	Admin RateLimit `yaml:"admin"`
+}
+
+// RateLimit is a token bucket refilled with Rate tokens per second up to
+// Burst. A zero Rate disables the limit.
+type RateLimit struct {
+	Rate  float64 `yaml:"rate"`
+	Burst int     `yaml:"burst"`
+}
+
+type AuthConfig struct {
+	TokensFile string `yaml:"tokens_file"`
+	// LegacyTokens enables the plain "token" header next to bearer JWTs.
+	LegacyTokens          bool   `yaml:"legacy_tokens"`
+	JWTHS256Secret        string `yaml:"jwt_hs256_secret"`
+	JWTHS256SecretFile    string `yaml:"jwt_hs256_secret_file"`
+	JWTRS256PublicKey     string `yaml:"jwt_rs256_public_key"`
+	JWTRS256PublicKeyFile string `yaml:"jwt_rs256_public_key_file"`
+}
+
+// Default returns the configuration used for everything the file and the
+// environment leave unset.
+func Default() Config {
+	return Config{
+		HTTP: HTTPConfig{
+			ListenAddr:      ":8080",
+			ReadTimeout:     10 * time.Second,
+			WriteTimeout:    30 * time.Second,
+			IdleTimeout:     120 * time.Second,
+			ShutdownTimeout: 15 * time.Second,
+		},
+		Database: DatabaseConfig{
+			MaxOpenConns:    20,
+			MaxIdleConns:    10,
+			ConnMaxLifetime: 30 * time.Minute,
+			ConnMaxIdleTime: 5 * time.Minute,
+		},
+		Cache: CacheConfig{
+			BannerTTL: 5 * time.Minute,
+			LocalSize: 10000,
+			LocalTTL:  5 * time.Second,
+			TokenTTL:  30 * time.Second,
+		},
+		Trash: TrashConfig{
+			Retention:     30 * 24 * time.Hour,
+			PurgeInterval: time.Hour,
+		},
+		Log: LogConfig{
+			Format: "text",
+			Level:  "info",
+		},
+		Tracing: TracingConfig{
+			Exporter:    "none",
+			SampleRatio: 1,
+			ServiceName: "banner-service",
+		},
+		RateLimit: RateLimitConfig{
+			User: RateLimit{Rate: 100, Burst: 200},
+		},
+		Auth: AuthConfig{
+			LegacyTokens: true,
+		},
+	}
+}
+
+// Load reads the configuration like Read and validates the result.
+func Load(path string) (Config, error) {
+	cfg, err := Read(path)
+	if err != nil {
+		return cfg, err
+	}
+	if err := cfg.Validate(); err != nil {
+		return cfg, err
+	}
+	return cfg, nil
+}
+
+// Read reads the file at path on top of the defaults and applies environment
+// overrides without validating the result, for callers that use only part
+// of the configuration. An empty path skips the file. JSON files are read
+// as YAML, of which JSON is a subset.
+func Read(path string) (Config, error) {
+	cfg := Default()
+
+	if path != "" {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return cfg, fmt.Errorf("failed to read config file: %v", err)
+		}
+		decoder := yaml.NewDecoder(bytes.NewReader(data))
+		decoder.KnownFields(true)
+		if err := decoder.Decode(&cfg); err != nil && !errors.Is(err, io.EOF) {
+			return cfg, fmt.Errorf("failed to parse config file %s: %v", path, err)
+		}
+	}
+
+	if err := cfg.applyEnv(os.LookupEnv); err != nil {
+		return cfg, err
+	}
+	return cfg, nil
+}
+
+// applyEnv overrides fields from the environment. The variable names match
+// the ones the service used before the config file existed. A variable that
+// is set but empty still applies, so e.g. REDIS_PASSWORD= clears a password
+// from the file; Validate rejects the fields that must not be empty.
+func (c *Config) applyEnv(lookup func(string) (string, bool)) error {
+	overrides := []struct {
+		name  string
+		apply func(string) error
+	}{
+		{"LISTEN_ADDR", setString(&c.HTTP.ListenAddr)},
+		{"HTTP_READ_TIMEOUT", setDuration(&c.HTTP.ReadTimeout)},
+		{"HTTP_WRITE_TIMEOUT", setDuration(&c.HTTP.WriteTimeout)},
+		{"HTTP_IDLE_TIMEOUT", setDuration(&c.HTTP.IdleTimeout)},
+		{"SHUTDOWN_TIMEOUT", setDuration(&c.HTTP.ShutdownTimeout)},
+		{"DATABASE_URL", setString(&c.Database.URL)},
+		{"DB_MAX_OPEN_CONNS", setInt(&c.Database.MaxOpenConns)},
+		{"DB_MAX_IDLE_CONNS", setInt(&c.Database.MaxIdleConns)},
+		{"DB_CONN_MAX_LIFETIME", setDuration(&c.Database.ConnMaxLifetime)},
+		{"DB_CONN_MAX_IDLE_TIME", setDuration(&c.Database.ConnMaxIdleTime)},
+		{"REDIS_URL", setString(&c.Redis.Addr)},
+		{"REDIS_DB", setInt(&c.Redis.DB)},
+		{"REDIS_PASSWORD", setString(&c.Redis.Password)},
+		{"REDIS_TLS", setBool(&c.Redis.TLS)},
+		{"REDIS_TLS_SERVER_NAME", setString(&c.Redis.TLSServerName)},
+		{"BANNER_CACHE_TTL", setDuration(&c.Cache.BannerTTL)},
+		{"BANNER_LOCAL_CACHE_SIZE", setInt(&c.Cache.LocalSize)},
+		{"BANNER_LOCAL_CACHE_TTL", setDuration(&c.Cache.LocalTTL)},
+		{"TOKEN_CACHE_TTL", setDuration(&c.Cache.TokenTTL)},
+		{"TRASH_RETENTION", setDuration(&c.Trash.Retention)},
+		{"TRASH_PURGE_INTERVAL", setDuration(&c.Trash.PurgeInterval)},
+		{"LOG_FORMAT", setString(&c.Log.Format)},
+		{"LOG_LEVEL", setString(&c.Log.Level)},
+		{"TRACING_EXPORTER", setString(&c.Tracing.Exporter)},
+		{"TRACING_ENDPOINT", setString(&c.Tracing.Endpoint)},
+		{"TRACING_SAMPLE_RATIO", setFloat(&c.Tracing.SampleRatio)},
+		{"TRACING_SERVICE_NAME", setString(&c.Tracing.ServiceName)},
+		{"RATE_LIMIT_BY_IP", setBool(&c.RateLimit.ByIP)},
+		{"RATE_LIMIT_USER_RATE", setFloat(&c.RateLimit.User.Rate)},
+		{"RATE_LIMIT_USER_BURST", setInt(&c.RateLimit.User.Burst)},
+		{"RATE_LIMIT_ADMIN_RATE", setFloat(&c.RateLimit.Admin.Rate)},
+		{"RATE_LIMIT_ADMIN_BURST", setInt(&c.RateLimit.Admin.Burst)},
+		{"TOKENS_FILE", setString(&c.Auth.TokensFile)},
+		{"AUTH_LEGACY_TOKENS", setBool(&c.Auth.LegacyTokens)},
+		{"JWT_HS256_SECRET", setString(&c.Auth.JWTHS256Secret)},
+		{"JWT_HS256_SECRET_FILE", setString(&c.Auth.JWTHS256SecretFile)},
+		{"JWT_RS256_PUBLIC_KEY", setString(&c.Auth.JWTRS256PublicKey)},
+		{"JWT_RS256_PUBLIC_KEY_FILE", setString(&c.Auth.JWTRS256PublicKeyFile)},
+	}
+
+	var errs []error
+	for _, override := range overrides {
+		value, ok := lookup(override.name)
+		if !ok {
+			continue
+		}
+		if err := override.apply(value); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %v", override.name, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Validate reports every invalid value at once.
+func (c Config) Validate() error {
+	var errs []error
+	check := func(ok bool, format string, args ...interface{}) {
+		if !ok {
+			errs = append(errs, fmt.Errorf(format, args...))
+		}
+	}
+
+	check(c.HTTP.ListenAddr != "", "http.listen_addr is required")
+	check(c.HTTP.ReadTimeout > 0, "http.read_timeout must be positive")
+	check(c.HTTP.WriteTimeout > 0, "http.write_timeout must be positive")
+	check(c.HTTP.IdleTimeout > 0, "http.idle_timeout must be positive")
+	check(c.HTTP.ShutdownTimeout > 0, "http.shutdown_timeout must be positive")
+
+	if err := c.Database.Validate(); err != nil {
+		errs = append(errs, err)
+	}
+
+	check(c.Redis.Addr != "", "redis.addr is required")
+	check(c.Redis.DB >= 0, "redis.db must not be negative")
+
+	check(c.Cache.BannerTTL > 0, "cache.banner_ttl must be positive")
+	check(c.Cache.LocalSize >= 0, "cache.local_size must not be negative")
+	check(c.Cache.LocalTTL >= 0, "cache.local_ttl must not be negative")
+	check(c.Cache.TokenTTL > 0, "cache.token_ttl must be positive")
+
+	check(c.Trash.Retention >= 0, "trash.retention must not be negative")
+	check(c.Trash.PurgeInterval > 0, "trash.purge_interval must be positive")
+
+	check(c.Log.Format == "text" || c.Log.Format == "json", "log.format must be text or json, got %q", c.Log.Format)
+	_, err := c.Log.SlogLevel()
+	check(err == nil, "log.level must be debug, info, warn or error, got %q", c.Log.Level)
+
+	check(c.Tracing.Exporter == "none" || c.Tracing.Exporter == "stdout" || c.Tracing.Exporter == "otlp",
+		"tracing.exporter must be none, stdout or otlp, got %q", c.Tracing.Exporter)
+	check(c.Tracing.SampleRatio >= 0 && c.Tracing.SampleRatio <= 1, "tracing.sample_ratio must be between 0 and 1")
+	check(c.Tracing.ServiceName != "", "tracing.service_name is required")
+
+	for _, limit := range []struct {
+		role string
+		RateLimit
+	}{{"user", c.RateLimit.User}, {"admin", c.RateLimit.Admin}} {
+		check(limit.Rate >= 0, "rate_limit.%s.rate must not be negative", limit.role)
+		check(limit.Rate == 0 || limit.Burst > 0, "rate_limit.%s.burst must be positive", limit.role)
+	}
+
+	check(c.Auth.JWTHS256Secret == "" || c.Auth.JWTHS256SecretFile == "",
+		"auth.jwt_hs256_secret and auth.jwt_hs256_secret_file are mutually exclusive")
+	check(c.Auth.JWTRS256PublicKey == "" || c.Auth.JWTRS256PublicKeyFile == "",
+		"auth.jwt_rs256_public_key and auth.jwt_rs256_public_key_file are mutually exclusive")
+
+	return errors.Join(errs...)
+}
+
+// Validate checks the database section alone, which is all the migrate
+// subcommand needs.
+func (d DatabaseConfig) Validate() error {
+	var errs []error
+	check := func(ok bool, message string) {
+		if !ok {
+			errs = append(errs, errors.New(message))
+		}
+	}
+
+	check(d.URL != "", "database.url is required")
+	check(d.MaxOpenConns >= 0, "database.max_open_conns must not be negative")
+	check(d.MaxIdleConns >= 0, "database.max_idle_conns must not be negative")
+	check(d.MaxOpenConns == 0 || d.MaxIdleConns <= d.MaxOpenConns,
+		"database.max_idle_conns must not exceed database.max_open_conns")
+	check(d.ConnMaxLifetime >= 0, "database.conn_max_lifetime must not be negative")
+	check(d.ConnMaxIdleTime >= 0, "database.conn_max_idle_time must not be negative")
+
+	return errors.Join(errs...)
+}
+
+func setString(field *string) func(string) error {
+	return func(value string) error {
+		*field = value
+		return nil
+	}
+}
+
+func setInt(field *int) func(string) error {
+	return func(value string) error {
+		parsed, err := strconv.Atoi(value)
+		if err != nil {
+			return err
+		}
+		*field = parsed
+		return nil
+	}
+}
+
+func setBool(field *bool) func(string) error {
+	return func(value string) error {
+		parsed, err := strconv.ParseBool(value)
+		if err != nil {
+			return err
+		}
+		*field = parsed
+		return nil
+	}
+}
+
+func setFloat(field *float64) func(string) error {
+	return func(value string) error {
+		parsed, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return err
+		}
+		*field = parsed
+		return nil
+	}
+}
+
+func setDuration(field *time.Duration) func(string) error {
+	return func(value string) error {
+		parsed, err := time.ParseDuration(value)
+		if err != nil {
+			return err
+		}
+		*field = parsed
+		return nil
+	}
+}
@@ -0,0 +1,136 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeFile(t *testing.T, name, content string) string {
+	path := filepath.Join(t.TempDir(), name)
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	return path
+}
+
+func TestLoadYAML(t *testing.T) {
+	path := writeFile(t, "config.yaml", `
+http:
+  listen_addr: ":9090"
+  shutdown_timeout: 30s
+database:
+  url: postgres://localhost/banners
+  max_open_conns: 50
+redis:
+  addr: localhost:6379
+  db: 2
+  tls: true
+cache:
+  banner_ttl: 1m
+log:
+  format: json
+  level: debug
+`)
+
+	cfg, err := Load(path)
+	require.NoError(t, err)
+	assert.Equal(t, ":9090", cfg.HTTP.ListenAddr)
+	assert.Equal(t, 30*time.Second, cfg.HTTP.ShutdownTimeout)
+	assert.Equal(t, 10*time.Second, cfg.HTTP.ReadTimeout, "Unset values should keep their defaults")
+	assert.Equal(t, 50, cfg.Database.MaxOpenConns)
+	assert.Equal(t, 2, cfg.Redis.DB)
+	assert.True(t, cfg.Redis.TLS)
+	assert.Equal(t, time.Minute, cfg.Cache.BannerTTL)
+	assert.Equal(t, "json", cfg.Log.Format)
+	assert.True(t, cfg.Auth.LegacyTokens)
+}
+
+func TestLoadJSON(t *testing.T) {
+	path := writeFile(t, "config.json", `{
+		"database": {"url": "postgres://localhost/banners"},
+		"redis": {"addr": "localhost:6379"},
+		"cache": {"local_size": 0}
+	}`)
+
+	cfg, err := Load(path)
+	require.NoError(t, err)
+	assert.Equal(t, "postgres://localhost/banners", cfg.Database.URL)
+	assert.Zero(t, cfg.Cache.LocalSize)
+}
+
+func TestEnvOverridesFile(t *testing.T) {
+	path := writeFile(t, "config.yaml", `
+database:
+  url: postgres://file/banners
+redis:
+  addr: file:6379
+`)
+	t.Setenv("DATABASE_URL", "postgres://env/banners")
+	t.Setenv("BANNER_LOCAL_CACHE_TTL", "250ms")
+	t.Setenv("AUTH_LEGACY_TOKENS", "false")
+
+	cfg, err := Load(path)
+	require.NoError(t, err)
+	assert.Equal(t, "postgres://env/banners", cfg.Database.URL)
+	assert.Equal(t, "file:6379", cfg.Redis.Addr)
+	assert.Equal(t, 250*time.Millisecond, cfg.Cache.LocalTTL)
+	assert.False(t, cfg.Auth.LegacyTokens)
+}
+
+func TestEmptyEnvClearsFileValue(t *testing.T) {
+	path := writeFile(t, "config.yaml", `
+database:
+  url: postgres://file/banners
+redis:
+  addr: file:6379
+  password: secret
+`)
+	t.Setenv("REDIS_PASSWORD", "")
+
+	cfg, err := Load(path)
+	require.NoError(t, err)
+	assert.Empty(t, cfg.Redis.Password)
+}
+
+func TestReadLeavesValidationToTheCaller(t *testing.T) {
+	path := writeFile(t, "config.yaml", "database: {url: postgres://localhost/banners}\n")
+	t.Setenv("REDIS_URL", "")
+
+	cfg, err := Read(path)
+	require.NoError(t, err)
+	assert.NoError(t, cfg.Database.Validate())
+	assert.ErrorContains(t, cfg.Validate(), "redis.addr is required")
+}
+
+func TestLoadRejectsInvalidValues(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		env     map[string]string
+		message string
+	}{
+		{"missing database url", "redis: {addr: localhost:6379}", map[string]string{"DATABASE_URL": ""}, "database.url is required"},
+		{"unknown field", "database: {url: x, pool: 5}\nredis: {addr: y}", nil, "field pool not found"},
+		{"idle above open", "database: {url: x, max_open_conns: 5, max_idle_conns: 10}\nredis: {addr: y}", nil, "max_idle_conns must not exceed"},
+		{"bad log level", "database: {url: x}\nredis: {addr: y}\nlog: {level: verbose}", nil, "log.level"},
+		{"negative trash retention", "database: {url: x}\nredis: {addr: y}\ntrash: {retention: -1h}", nil, "trash.retention"},
+		{"rate limit without burst", "database: {url: x}\nredis: {addr: y}\nrate_limit: {user: {rate: 10, burst: 0}}", nil, "rate_limit.user.burst"},
+		{"empty env redis addr", "database: {url: x}\nredis: {addr: y}", map[string]string{"REDIS_URL": ""}, "redis.addr is required"},
+		{"empty env number", "database: {url: x}\nredis: {addr: y}", map[string]string{"REDIS_DB": ""}, "REDIS_DB"},
+		{"bad env duration", "database: {url: x}\nredis: {addr: y}", map[string]string{"BANNER_CACHE_TTL": "soon"}, "BANNER_CACHE_TTL"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for name, value := range tt.env {
+				t.Setenv(name, value)
+			}
+			_, err := Load(writeFile(t, "config.yaml", tt.content))
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.message)
+		})
+	}
+}
@@ -0,0 +1,38 @@
+package config
+
+import (
+	"fmt"
+	"io"
+	"log/slog"
+	"strings"
+)
+
+func (c LogConfig) SlogLevel() (slog.Level, error) {
+	switch strings.ToLower(c.Level) {
+	case "debug":
+		return slog.LevelDebug, nil
+	case "info":
+		return slog.LevelInfo, nil
+	case "warn":
+		return slog.LevelWarn, nil
+	case "error":
+		return slog.LevelError, nil
+	}
+	return slog.LevelInfo, fmt.Errorf("unknown log level %q", c.Level)
+}
+
+// NewLogger builds a logger writing to w in the configured format and level.
+func (c LogConfig) NewLogger(w io.Writer) *slog.Logger {
+	level, _ := c.SlogLevel()
+	options := &slog.HandlerOptions{Level: level}
+	if c.Format == "json" {
+		return slog.New(slog.NewJSONHandler(w, options))
+	}
+	return slog.New(slog.NewTextHandler(w, options))
+}
+
+// NewAccessLogger builds the logger for access log lines, which are always
+// written as JSON so they can be shipped and parsed regardless of Format.
+func (c LogConfig) NewAccessLogger(w io.Writer) *slog.Logger {
+	return slog.New(slog.NewJSONHandler(w, nil))
+}
@@ -1,14 +1,218 @@
 package db
 
 import (
+	"embed"
+	"fmt"
+	"io/fs"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"time"
+
 	"gorm.io/gorm"
 )
 
+//go:embed migrations/*.sql
+var migrationFiles embed.FS
+
+// migrationLockID is the Postgres advisory lock held while migrations run,
+// so replicas starting at the same time apply each migration once.
+const migrationLockID = 4201700
+
+var migrationFilePattern = regexp.MustCompile(`^(\d+)_([a-z0-9_]+)\.(up|down)\.sql$`)
+
+type migration struct {
+	Version int64
+	Name    string
+	Up      string
+	Down    string
+}
+
+// MigrationStatus describes a known migration and when it was applied. A
+// nil AppliedAt means the migration is pending.
+type MigrationStatus struct {
+	Version   int64
+	Name      string
+	AppliedAt *time.Time
+}
+
+type appliedMigration struct {
+	Version   int64
+	Name      string
+	AppliedAt time.Time
+}
+
+func (appliedMigration) TableName() string {
+	return "schema_migrations"
+}
+
+// loadMigrations reads NNNN_name.up.sql / NNNN_name.down.sql pairs from fsys
+// and returns them ordered by version.
+func loadMigrations(fsys fs.FS) ([]migration, error) {
+	files, err := fs.Glob(fsys, "migrations/*.sql")
+	if err != nil {
+		return nil, err
+	}
+
+	byVersion := make(map[int64]*migration)
+	for _, file := range files {
+		match := migrationFilePattern.FindStringSubmatch(path.Base(file))
+		if match == nil {
+			return nil, fmt.Errorf("unexpected migration file name %q", file)
+		}
+		version, err := strconv.ParseInt(match[1], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid migration version in %q: %v", file, err)
+		}
+		body, err := fs.ReadFile(fsys, file)
+		if err != nil {
+			return nil, err
+		}
+
+		m, ok := byVersion[version]
+		if !ok {
+			m = &migration{Version: version, Name: match[2]}
+			byVersion[version] = m
+		} else if m.Name != match[2] {
+			return nil, fmt.Errorf("migration %d is named both %q and %q", version, m.Name, match[2])
+		}
+		if match[3] == "up" {
+			m.Up = string(body)
+		} else {
+			m.Down = string(body)
+		}
+	}
+
+	migrations := make([]migration, 0, len(byVersion))
+	for _, m := range byVersion {
+		if m.Up == "" || m.Down == "" {
+			return nil, fmt.Errorf("migration %04d_%s must have both up and down files", m.Version, m.Name)
+		}
+		migrations = append(migrations, *m)
+	}
+	sort.Slice(migrations, func(i, j int) bool { return migrations[i].Version < migrations[j].Version })
+	return migrations, nil
+}
+
+// withMigrationLock runs fn in a transaction holding migrationLockID, after
+// making sure the schema_migrations table exists. Everything fn does is
+// rolled back if it returns an error.
+func withMigrationLock(db *gorm.DB, fn func(tx *gorm.DB, applied map[int64]bool) error) error {
+	return db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec("SELECT pg_advisory_xact_lock(?)", migrationLockID).Error; err != nil {
+			return fmt.Errorf("failed to acquire migration lock: %v", err)
+		}
+		if err := tx.Exec(`CREATE TABLE IF NOT EXISTS schema_migrations (
+			version BIGINT PRIMARY KEY,
+			name TEXT NOT NULL,
+			applied_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP
+		)`).Error; err != nil {
+			return fmt.Errorf("failed to create schema_migrations: %v", err)
+		}
+
+		var versions []int64
+		if err := tx.Model(&appliedMigration{}).Pluck("version", &versions).Error; err != nil {
+			return err
+		}
+		applied := make(map[int64]bool, len(versions))
+		for _, version := range versions {
+			applied[version] = true
+		}
+		return fn(tx, applied)
+	})
+}
+
+// Migrate applies every pending migration in version order. Either all of
+// them are applied or none is.
 func Migrate(db *gorm.DB) error {
+	migrations, err := loadMigrations(migrationFiles)
+	if err != nil {
+		return err
+	}
+
+	return withMigrationLock(db, func(tx *gorm.DB, applied map[int64]bool) error {
+		for _, m := range migrations {
+			if applied[m.Version] {
+				continue
+			}
+			if err := tx.Exec(m.Up).Error; err != nil {
+				return fmt.Errorf("migration %04d_%s failed: %v", m.Version, m.Name, err)
+			}
+			if err := tx.Create(&appliedMigration{Version: m.Version, Name: m.Name, AppliedAt: time.Now()}).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
 
-	if err := db.AutoMigrate(&Banner{}, &BannerFeatureTag{}); err != nil {
+// MigrateDown reverts the last steps applied migrations, newest first.
+func MigrateDown(db *gorm.DB, steps int) error {
+	migrations, err := loadMigrations(migrationFiles)
+	if err != nil {
 		return err
 	}
-	return nil
 
+	return withMigrationLock(db, func(tx *gorm.DB, applied map[int64]bool) error {
+		for i := len(migrations) - 1; i >= 0 && steps > 0; i-- {
+			m := migrations[i]
+			if !applied[m.Version] {
+				continue
+			}
+			if err := tx.Exec(m.Down).Error; err != nil {
+				return fmt.Errorf("reverting migration %04d_%s failed: %v", m.Version, m.Name, err)
+			}
+			if err := tx.Where("version = ?", m.Version).Delete(&appliedMigration{}).Error; err != nil {
+				return err
+			}
+			steps--
+		}
+		return nil
+	})
+}
+
+// MigrationStatuses lists every known migration together with the time it
+// was applied, if it was.
+func MigrationStatuses(db *gorm.DB) ([]MigrationStatus, error) {
+	migrations, err := loadMigrations(migrationFiles)
+	if err != nil {
+		return nil, err
+	}
+
+	var applied []appliedMigration
+	if db.Migrator().HasTable(&appliedMigration{}) {
+		if err := db.Find(&applied).Error; err != nil {
+			return nil, err
+		}
+	}
+	appliedAt := make(map[int64]time.Time, len(applied))
+	for _, a := range applied {
+		appliedAt[a.Version] = a.AppliedAt
+	}
+
+	statuses := make([]MigrationStatus, 0, len(migrations))
+	for _, m := range migrations {
+		status := MigrationStatus{Version: m.Version, Name: m.Name}
+		if at, ok := appliedAt[m.Version]; ok {
+			status.AppliedAt = &at
+		}
+		statuses = append(statuses, status)
+	}
+	return statuses, nil
+}
+
+// CheckMigrations reports an error naming the first migration that has not
+// been applied to the database.
+func CheckMigrations(db *gorm.DB) error {
+	statuses, err := MigrationStatuses(db)
+	if err != nil {
+		return err
+	}
+	for _, status := range statuses {
+		if status.AppliedAt == nil {
+			return fmt.Errorf("migration %04d_%s is not applied", status.Version, status.Name)
+		}
+	}
+	return nil
 }
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS banner_feature_tags;
+DROP TABLE IF EXISTS banners;
@@ -0,0 +1,35 @@
+CREATE TABLE IF NOT EXISTS banners (
+    id BIGSERIAL PRIMARY KEY,
+    content JSON,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    is_active BOOLEAN NOT NULL DEFAULT FALSE
+);
+
+CREATE TABLE IF NOT EXISTS banner_feature_tags (
+    id BIGSERIAL PRIMARY KEY,
+    banner_id BIGINT NOT NULL,
+    feature_id BIGINT NOT NULL,
+    tag_id BIGINT NOT NULL
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_feature_tag ON banner_feature_tags (feature_id, tag_id);
+CREATE INDEX IF NOT EXISTS idx_banner_feature_tags_on_banner_id ON banner_feature_tags (banner_id);
+CREATE INDEX IF NOT EXISTS idx_banner_feature_tags_on_tag_id ON banner_feature_tags (tag_id);
+
+-- Schemas created by gorm AutoMigrate have no foreign key, so deleted
+-- banners may have left rows behind that still hold their feature/tag pair.
+DELETE FROM banner_feature_tags bft
+WHERE NOT EXISTS (SELECT 1 FROM banners b WHERE b.id = bft.banner_id);
+
+DO $$
+BEGIN
+    IF NOT EXISTS (
+        SELECT 1 FROM pg_constraint
+        WHERE conrelid = 'banner_feature_tags'::regclass AND contype = 'f'
+    ) THEN
+        ALTER TABLE banner_feature_tags
+            ADD CONSTRAINT fk_banner FOREIGN KEY (banner_id) REFERENCES banners (id) ON DELETE CASCADE;
+    END IF;
+END
+$$;
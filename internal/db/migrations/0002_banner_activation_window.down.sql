@@ -0,0 +1,2 @@
+ALTER TABLE banners DROP COLUMN IF EXISTS active_until;
+ALTER TABLE banners DROP COLUMN IF EXISTS active_from;
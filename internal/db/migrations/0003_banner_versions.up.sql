@@ -0,0 +1,14 @@
+CREATE TABLE IF NOT EXISTS banner_versions (
+    id BIGSERIAL PRIMARY KEY,
+    banner_id BIGINT NOT NULL,
+    version BIGINT NOT NULL,
+    content JSON,
+    is_active BOOLEAN NOT NULL DEFAULT FALSE,
+    active_from TIMESTAMPTZ,
+    active_until TIMESTAMPTZ,
+    feature_id BIGINT NOT NULL DEFAULT 0,
+    tag_ids INTEGER[],
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_banner_version ON banner_versions (banner_id, version);
@@ -0,0 +1,6 @@
+CREATE TABLE IF NOT EXISTS feature_schemas (
+    feature_id BIGINT PRIMARY KEY,
+    schema JSON NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
@@ -0,0 +1,6 @@
+-- Banners in the trash have already lost their feature/tag pairs, so they
+-- are dropped rather than brought back.
+DELETE FROM banners WHERE deleted_at IS NOT NULL;
+
+DROP INDEX IF EXISTS idx_banners_deleted_at;
+ALTER TABLE banners DROP COLUMN IF EXISTS deleted_at;
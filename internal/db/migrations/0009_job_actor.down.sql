@@ -0,0 +1,3 @@
+ALTER TABLE jobs DROP COLUMN IF EXISTS request_id;
+ALTER TABLE jobs DROP COLUMN IF EXISTS actor_role;
+ALTER TABLE jobs DROP COLUMN IF EXISTS actor_token_id;
@@ -0,0 +1,5 @@
+-- Bulk deletes record audit events in the background, on behalf of the
+-- request that queued them.
+ALTER TABLE jobs ADD COLUMN IF NOT EXISTS actor_token_id TEXT NOT NULL DEFAULT '';
+ALTER TABLE jobs ADD COLUMN IF NOT EXISTS actor_role TEXT NOT NULL DEFAULT '';
+ALTER TABLE jobs ADD COLUMN IF NOT EXISTS request_id TEXT NOT NULL DEFAULT '';
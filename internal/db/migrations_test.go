@@ -0,0 +1,64 @@
+package db
+
+import (
+	"testing"
+	"testing/fstest"
+)
+
+func TestEmbeddedMigrationsLoad(t *testing.T) {
+	migrations, err := loadMigrations(migrationFiles)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(migrations) == 0 {
+		t.Fatal("no migrations embedded")
+	}
+	for i, m := range migrations {
+		if m.Version != int64(i+1) {
+			t.Fatalf("migration %d has version %d, versions must be consecutive", i, m.Version)
+		}
+	}
+}
+
+func TestLoadMigrationsOrdersByVersion(t *testing.T) {
+	fsys := fstest.MapFS{
+		"migrations/0010_second.up.sql":   {Data: []byte("up 10")},
+		"migrations/0010_second.down.sql": {Data: []byte("down 10")},
+		"migrations/0002_first.up.sql":    {Data: []byte("up 2")},
+		"migrations/0002_first.down.sql":  {Data: []byte("down 2")},
+	}
+
+	migrations, err := loadMigrations(fsys)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(migrations) != 2 || migrations[0].Version != 2 || migrations[1].Version != 10 {
+		t.Fatalf("unexpected order: %+v", migrations)
+	}
+	if migrations[0].Name != "first" || migrations[0].Up != "up 2" || migrations[0].Down != "down 2" {
+		t.Fatalf("unexpected migration: %+v", migrations[0])
+	}
+}
+
+func TestLoadMigrationsRejectsInvalidSets(t *testing.T) {
+	tests := map[string]fstest.MapFS{
+		"missing down": {
+			"migrations/0001_init.up.sql": {Data: []byte("up")},
+		},
+		"bad name": {
+			"migrations/init.sql": {Data: []byte("up")},
+		},
+		"name mismatch": {
+			"migrations/0001_init.up.sql":    {Data: []byte("up")},
+			"migrations/0001_other.down.sql": {Data: []byte("down")},
+		},
+	}
+
+	for name, fsys := range tests {
+		t.Run(name, func(t *testing.T) {
+			if _, err := loadMigrations(fsys); err == nil {
+				t.Fatal("expected an error")
+			}
+		})
+	}
+}
@@ -3,14 +3,41 @@ package db
 import (
 	"encoding/json"
 	"time"
+
+	"github.com/lib/pq"
+	"gorm.io/gorm"
 )
 
 type Banner struct {
-	ID        uint            `gorm:"primaryKey"`
-	Content   json.RawMessage `gorm:"type:json"`
-	CreatedAt time.Time       `gorm:"autoCreateTime"`
-	UpdatedAt time.Time       `gorm:"autoUpdateTime"`
-	IsActive  bool
+	ID          uint            `gorm:"primaryKey"`
+	Content     json.RawMessage `gorm:"type:json"`
+	CreatedAt   time.Time       `gorm:"autoCreateTime"`
+	UpdatedAt   time.Time       `gorm:"autoUpdateTime"`
+	IsActive    bool
+	ActiveFrom  *time.Time
+	ActiveUntil *time.Time
+	// DeletedAt marks banners moved to the trash. Such banners have no
+	// feature/tag pairs; the pairs are restored from the latest version.
+	DeletedAt gorm.DeletedAt `gorm:"index"`
+}
+
+// VisibleAt reports whether regular users may see the banner at the given
+// moment: it must be active and inside its activation window, if any.
+func (b Banner) VisibleAt(now time.Time) bool {
+	return IsVisible(b.IsActive, b.ActiveFrom, b.ActiveUntil, now)
+}
+
+func IsVisible(isActive bool, activeFrom, activeUntil *time.Time, now time.Time) bool {
+	if !isActive {
+		return false
+	}
+	if activeFrom != nil && now.Before(*activeFrom) {
+		return false
+	}
+	if activeUntil != nil && !now.Before(*activeUntil) {
+		return false
+	}
+	return true
 }
 
 type BannerFeatureTag struct {
@@ -23,3 +50,103 @@ type BannerFeatureTag struct {
 func (BannerFeatureTag) TableName() string {
 	return "banner_feature_tags"
 }
+
+type BannerVersion struct {
+	ID          uint            `gorm:"primaryKey"`
+	BannerID    uint            `gorm:"index:idx_banner_version,unique"`
+	Version     int             `gorm:"index:idx_banner_version,unique"`
+	Content     json.RawMessage `gorm:"type:json"`
+	IsActive    bool
+	ActiveFrom  *time.Time
+	ActiveUntil *time.Time
+	FeatureID   int
+	TagIDs      pq.Int64Array `gorm:"type:integer[]"`
+	CreatedAt   time.Time     `gorm:"autoCreateTime"`
+}
+
+func (BannerVersion) TableName() string {
+	return "banner_versions"
+}
+
+type Token struct {
+	ID        uint   `gorm:"primaryKey"`
+	TokenHash string `gorm:"uniqueIndex;not null"`
+	Role      string `gorm:"not null"`
+	ExpiresAt *time.Time
+	Revoked   bool      `gorm:"not null;default:false"`
+	CreatedAt time.Time `gorm:"autoCreateTime"`
+}
+
+func (Token) TableName() string {
+	return "tokens"
+}
+
+const (
+	JobPending = "pending"
+	JobRunning = "running"
+	JobDone    = "done"
+	JobFailed  = "failed"
+)
+
+type Job struct {
+	ID        uint   `gorm:"primaryKey"`
+	Kind      string `gorm:"not null"`
+	Status    string `gorm:"index;not null"`
+	FeatureID *int
+	TagID     *int
+	Deleted   int    `gorm:"not null;default:0"`
+	Error     string `gorm:"not null;default:''"`
+	// The token and request that queued the job, for its audit events.
+	ActorTokenID string    `gorm:"not null;default:''"`
+	ActorRole    string    `gorm:"not null;default:''"`
+	RequestID    string    `gorm:"not null;default:''"`
+	CreatedAt    time.Time `gorm:"autoCreateTime"`
+	UpdatedAt    time.Time `gorm:"autoUpdateTime"`
+}
+
+func (Job) TableName() string {
+	return "jobs"
+}
+
+// FeatureSchema is the JSON Schema banner content of a feature must satisfy.
+// Features without a row accept any content.
+type FeatureSchema struct {
+	FeatureID int             `gorm:"primaryKey;autoIncrement:false"`
+	Schema    json.RawMessage `gorm:"type:json;not null"`
+	CreatedAt time.Time       `gorm:"autoCreateTime"`
+	UpdatedAt time.Time       `gorm:"autoUpdateTime"`
+}
+
+func (FeatureSchema) TableName() string {
+	return "feature_schemas"
+}
+
+const (
+	AuditBannerCreate   = "banner.create"
+	AuditBannerUpdate   = "banner.update"
+	AuditBannerDelete   = "banner.delete"
+	AuditBannerRestore  = "banner.restore"
+	AuditBannerRollback = "banner.rollback"
+	AuditBannerPurge    = "banner.purge"
+
+	// AuditSystemActor is the actor of events the service records on its
+	// own, such as purging the trash.
+	AuditSystemActor = "system"
+)
+
+// AuditEvent records who changed a banner and how. Diff maps every changed
+// field to its value before and after the change.
+type AuditEvent struct {
+	ID           uint            `gorm:"primaryKey"`
+	ActorTokenID string          `gorm:"index;not null"`
+	ActorRole    string          `gorm:"not null"`
+	Action       string          `gorm:"not null"`
+	BannerID     uint            `gorm:"index"`
+	Diff         json.RawMessage `gorm:"type:json"`
+	RequestID    string          `gorm:"not null;default:''"`
+	CreatedAt    time.Time       `gorm:"autoCreateTime;index"`
+}
+
+func (AuditEvent) TableName() string {
+	return "audit_events"
+}
@@ -18,12 +18,61 @@ import (
 	"github.com/oapi-codegen/runtime"
 )
 
-// GetBannerParams defines parameters for GetBanner.
-type GetBannerParams struct {
+// Defines values for GetBannerExportParamsFormat.
+const (
+	GetBannerExportParamsFormatCsv    GetBannerExportParamsFormat = "csv"
+	GetBannerExportParamsFormatNdjson GetBannerExportParamsFormat = "ndjson"
+)
+
+// Defines values for PostBannerImportParamsFormat.
+const (
+	PostBannerImportParamsFormatCsv    PostBannerImportParamsFormat = "csv"
+	PostBannerImportParamsFormatNdjson PostBannerImportParamsFormat = "ndjson"
+)
+
+// Defines values for PostBannerImportParamsMode.
+const (
+	Fail   PostBannerImportParamsMode = "fail"
+	Skip   PostBannerImportParamsMode = "skip"
+	Upsert PostBannerImportParamsMode = "upsert"
+)
+
+// Defines values for PostTokensJSONBodyRole.
+const (
+	Admin PostTokensJSONBodyRole = "admin"
+	User  PostTokensJSONBodyRole = "user"
+)
+
+// GetAuditParams defines parameters for GetAudit.
+type GetAuditParams struct {
+	BannerId *int       `form:"banner_id,omitempty" json:"banner_id,omitempty"`
+	Actor    *string    `form:"actor,omitempty" json:"actor,omitempty"`
+	From     *time.Time `form:"from,omitempty" json:"from,omitempty"`
+	To       *time.Time `form:"to,omitempty" json:"to,omitempty"`
+	Limit    *int       `form:"limit,omitempty" json:"limit,omitempty"`
+	Offset   *int       `form:"offset,omitempty" json:"offset,omitempty"`
+
+	// Token Токен админа
+	Token *string `json:"token,omitempty"`
+}
+
+// DeleteBannerParams defines parameters for DeleteBanner.
+type DeleteBannerParams struct {
 	FeatureId *int `form:"feature_id,omitempty" json:"feature_id,omitempty"`
 	TagId     *int `form:"tag_id,omitempty" json:"tag_id,omitempty"`
-	Limit     *int `form:"limit,omitempty" json:"limit,omitempty"`
-	Offset    *int `form:"offset,omitempty" json:"offset,omitempty"`
+
+	// Token Токен админа
+	Token *string `json:"token,omitempty"`
+}
+
+// GetBannerParams defines parameters for GetBanner.
+type GetBannerParams struct {
+	FeatureId *int    `form:"feature_id,omitempty" json:"feature_id,omitempty"`
+	TagId     *int    `form:"tag_id,omitempty" json:"tag_id,omitempty"`
+	Limit     *int    `form:"limit,omitempty" json:"limit,omitempty"`
+	Offset    *int    `form:"offset,omitempty" json:"offset,omitempty"`
+	Cursor    *string `form:"cursor,omitempty" json:"cursor,omitempty"`
+	Deleted   *bool   `form:"deleted,omitempty" json:"deleted,omitempty"`
 
 	// Token Токен админа
 	Token *string `json:"token,omitempty"`
@@ -31,6 +80,12 @@ type GetBannerParams struct {
 
 // PostBannerJSONBody defines parameters for PostBanner.
 type PostBannerJSONBody struct {
+	// ActiveFrom Начало периода показа баннера
+	ActiveFrom *time.Time `json:"active_from,omitempty"`
+
+	// ActiveUntil Окончание периода показа баннера
+	ActiveUntil *time.Time `json:"active_until,omitempty"`
+
 	// Content Содержимое баннера
 	Content *map[string]interface{} `json:"content,omitempty"`
 
@@ -50,6 +105,48 @@ type PostBannerParams struct {
 	Token *string `json:"token,omitempty"`
 }
 
+// GetBannerExportParams defines parameters for GetBannerExport.
+type GetBannerExportParams struct {
+	Format *GetBannerExportParamsFormat `form:"format,omitempty" json:"format,omitempty"`
+
+	// Token Токен админа
+	Token *string `json:"token,omitempty"`
+}
+
+// GetBannerExportParamsFormat defines parameters for GetBannerExport.
+type GetBannerExportParamsFormat string
+
+// PostBannerImportParams defines parameters for PostBannerImport.
+type PostBannerImportParams struct {
+	Format *PostBannerImportParamsFormat `form:"format,omitempty" json:"format,omitempty"`
+	Mode   *PostBannerImportParamsMode   `form:"mode,omitempty" json:"mode,omitempty"`
+
+	// Token Токен админа
+	Token *string `json:"token,omitempty"`
+}
+
+// PostBannerImportParamsFormat defines parameters for PostBannerImport.
+type PostBannerImportParamsFormat string
+
+// PostBannerImportParamsMode defines parameters for PostBannerImport.
+type PostBannerImportParamsMode string
+
+// GetBannerVersionsIdParams defines parameters for GetBannerVersionsId.
+type GetBannerVersionsIdParams struct {
+	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
+
+	// Token Токен админа
+	Token *string `json:"token,omitempty"`
+}
+
+// PutBannerVersionsIdActivateParams defines parameters for PutBannerVersionsIdActivate.
+type PutBannerVersionsIdActivateParams struct {
+	Version int `form:"version" json:"version"`
+
+	// Token Токен админа
+	Token *string `json:"token,omitempty"`
+}
+
 // DeleteBannerIdParams defines parameters for DeleteBannerId.
 type DeleteBannerIdParams struct {
 	// Token Токен админа
@@ -58,6 +155,12 @@ type DeleteBannerIdParams struct {
 
 // PatchBannerIdJSONBody defines parameters for PatchBannerId.
 type PatchBannerIdJSONBody struct {
+	// ActiveFrom Начало периода показа баннера, null снимает ограничение
+	ActiveFrom *time.Time `json:"active_from"`
+
+	// ActiveUntil Окончание периода показа баннера, null снимает ограничение
+	ActiveUntil *time.Time `json:"active_until"`
+
 	// Content Содержимое баннера
 	Content *map[string]interface{} `json:"content"`
 
@@ -77,6 +180,69 @@ type PatchBannerIdParams struct {
 	Token *string `json:"token,omitempty"`
 }
 
+// PostBannerIdRestoreParams defines parameters for PostBannerIdRestore.
+type PostBannerIdRestoreParams struct {
+	// Token Токен админа
+	Token *string `json:"token,omitempty"`
+}
+
+// DeleteFeatureIdSchemaParams defines parameters for DeleteFeatureIdSchema.
+type DeleteFeatureIdSchemaParams struct {
+	// Token Токен админа
+	Token *string `json:"token,omitempty"`
+}
+
+// GetFeatureIdSchemaParams defines parameters for GetFeatureIdSchema.
+type GetFeatureIdSchemaParams struct {
+	// Token Токен админа
+	Token *string `json:"token,omitempty"`
+}
+
+// PutFeatureIdSchemaJSONBody defines parameters for PutFeatureIdSchema.
+type PutFeatureIdSchemaJSONBody map[string]interface{}
+
+// PutFeatureIdSchemaParams defines parameters for PutFeatureIdSchema.
+type PutFeatureIdSchemaParams struct {
+	// Token Токен админа
+	Token *string `json:"token,omitempty"`
+}
+
+// GetJobsIdParams defines parameters for GetJobsId.
+type GetJobsIdParams struct {
+	// Token Токен админа
+	Token *string `json:"token,omitempty"`
+}
+
+// GetTokensParams defines parameters for GetTokens.
+type GetTokensParams struct {
+	// Token Токен админа
+	Token *string `json:"token,omitempty"`
+}
+
+// PostTokensJSONBody defines parameters for PostTokens.
+type PostTokensJSONBody struct {
+	// Role Роль владельца токена
+	Role *PostTokensJSONBodyRole `json:"role,omitempty"`
+
+	// TtlSeconds Время жизни токена в секундах, без него токен бессрочный
+	TtlSeconds *int `json:"ttl_seconds,omitempty"`
+}
+
+// PostTokensParams defines parameters for PostTokens.
+type PostTokensParams struct {
+	// Token Токен админа
+	Token *string `json:"token,omitempty"`
+}
+
+// PostTokensJSONBodyRole defines parameters for PostTokens.
+type PostTokensJSONBodyRole string
+
+// DeleteTokensIdParams defines parameters for DeleteTokensId.
+type DeleteTokensIdParams struct {
+	// Token Токен админа
+	Token *string `json:"token,omitempty"`
+}
+
 // GetUserBannerParams defines parameters for GetUserBanner.
 type GetUserBannerParams struct {
 	TagId           int   `form:"tag_id" json:"tag_id"`
@@ -87,12 +253,36 @@ type GetUserBannerParams struct {
 	Token *string `json:"token,omitempty"`
 }
 
+// PostUserBannerBatchJSONBody defines parameters for PostUserBannerBatch.
+type PostUserBannerBatchJSONBody struct {
+	// FeatureIds Идентификаторы фич, не больше 100
+	FeatureIds []int `json:"feature_ids"`
+
+	// TagId Тэг пользователя
+	TagId int `json:"tag_id"`
+}
+
+// PostUserBannerBatchParams defines parameters for PostUserBannerBatch.
+type PostUserBannerBatchParams struct {
+	// Token Токен пользователя
+	Token *string `json:"token,omitempty"`
+}
+
 // PostBannerJSONRequestBody defines body for PostBanner for application/json ContentType.
 type PostBannerJSONRequestBody PostBannerJSONBody
 
 // PatchBannerIdJSONRequestBody defines body for PatchBannerId for application/json ContentType.
 type PatchBannerIdJSONRequestBody PatchBannerIdJSONBody
 
+// PutFeatureIdSchemaJSONRequestBody defines body for PutFeatureIdSchema for application/json ContentType.
+type PutFeatureIdSchemaJSONRequestBody PutFeatureIdSchemaJSONBody
+
+// PostTokensJSONRequestBody defines body for PostTokens for application/json ContentType.
+type PostTokensJSONRequestBody PostTokensJSONBody
+
+// PostUserBannerBatchJSONRequestBody defines body for PostUserBannerBatch for application/json ContentType.
+type PostUserBannerBatchJSONRequestBody PostUserBannerBatchJSONBody
+
 // RequestEditorFn  is the function signature for the RequestEditor callback function
 type RequestEditorFn func(ctx context.Context, req *http.Request) error
 
@@ -166,6 +356,12 @@ func WithRequestEditorFn(fn RequestEditorFn) ClientOption {
 
 // The interface specification for the client above.
 type ClientInterface interface {
+	// GetAudit request
+	GetAudit(ctx context.Context, params *GetAuditParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// DeleteBanner request
+	DeleteBanner(ctx context.Context, params *DeleteBannerParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
 	// GetBanner request
 	GetBanner(ctx context.Context, params *GetBannerParams, reqEditors ...RequestEditorFn) (*http.Response, error)
 
@@ -174,6 +370,18 @@ type ClientInterface interface {
 
 	PostBanner(ctx context.Context, params *PostBannerParams, body PostBannerJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
 
+	// GetBannerExport request
+	GetBannerExport(ctx context.Context, params *GetBannerExportParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// PostBannerImportWithBody request with any body
+	PostBannerImportWithBody(ctx context.Context, params *PostBannerImportParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetBannerVersionsId request
+	GetBannerVersionsId(ctx context.Context, id int, params *GetBannerVersionsIdParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// PutBannerVersionsIdActivate request
+	PutBannerVersionsIdActivate(ctx context.Context, id int, params *PutBannerVersionsIdActivateParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
 	// DeleteBannerId request
 	DeleteBannerId(ctx context.Context, id int, params *DeleteBannerIdParams, reqEditors ...RequestEditorFn) (*http.Response, error)
 
@@ -182,8 +390,65 @@ type ClientInterface interface {
 
 	PatchBannerId(ctx context.Context, id int, params *PatchBannerIdParams, body PatchBannerIdJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
 
+	// PostBannerIdRestore request
+	PostBannerIdRestore(ctx context.Context, id int, params *PostBannerIdRestoreParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// DeleteFeatureIdSchema request
+	DeleteFeatureIdSchema(ctx context.Context, id int, params *DeleteFeatureIdSchemaParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetFeatureIdSchema request
+	GetFeatureIdSchema(ctx context.Context, id int, params *GetFeatureIdSchemaParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// PutFeatureIdSchemaWithBody request with any body
+	PutFeatureIdSchemaWithBody(ctx context.Context, id int, params *PutFeatureIdSchemaParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	PutFeatureIdSchema(ctx context.Context, id int, params *PutFeatureIdSchemaParams, body PutFeatureIdSchemaJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetJobsId request
+	GetJobsId(ctx context.Context, id int, params *GetJobsIdParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetTokens request
+	GetTokens(ctx context.Context, params *GetTokensParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// PostTokensWithBody request with any body
+	PostTokensWithBody(ctx context.Context, params *PostTokensParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	PostTokens(ctx context.Context, params *PostTokensParams, body PostTokensJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// DeleteTokensId request
+	DeleteTokensId(ctx context.Context, id string, params *DeleteTokensIdParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
 	// GetUserBanner request
 	GetUserBanner(ctx context.Context, params *GetUserBannerParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// PostUserBannerBatchWithBody request with any body
+	PostUserBannerBatchWithBody(ctx context.Context, params *PostUserBannerBatchParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	PostUserBannerBatch(ctx context.Context, params *PostUserBannerBatchParams, body PostUserBannerBatchJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+}
+
+func (c *Client) GetAudit(ctx context.Context, params *GetAuditParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetAuditRequest(c.Server, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) DeleteBanner(ctx context.Context, params *DeleteBannerParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewDeleteBannerRequest(c.Server, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
 }
 
 func (c *Client) GetBanner(ctx context.Context, params *GetBannerParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
@@ -222,6 +487,54 @@ func (c *Client) PostBanner(ctx context.Context, params *PostBannerParams, body
 	return c.Client.Do(req)
 }
 
+func (c *Client) GetBannerExport(ctx context.Context, params *GetBannerExportParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetBannerExportRequest(c.Server, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PostBannerImportWithBody(ctx context.Context, params *PostBannerImportParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostBannerImportRequestWithBody(c.Server, params, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetBannerVersionsId(ctx context.Context, id int, params *GetBannerVersionsIdParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetBannerVersionsIdRequest(c.Server, id, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PutBannerVersionsIdActivate(ctx context.Context, id int, params *PutBannerVersionsIdActivateParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPutBannerVersionsIdActivateRequest(c.Server, id, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
 func (c *Client) DeleteBannerId(ctx context.Context, id int, params *DeleteBannerIdParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
 	req, err := NewDeleteBannerIdRequest(c.Server, id, params)
 	if err != nil {
@@ -258,8 +571,8 @@ func (c *Client) PatchBannerId(ctx context.Context, id int, params *PatchBannerI
 	return c.Client.Do(req)
 }
 
-func (c *Client) GetUserBanner(ctx context.Context, params *GetUserBannerParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewGetUserBannerRequest(c.Server, params)
+func (c *Client) PostBannerIdRestore(ctx context.Context, id int, params *PostBannerIdRestoreParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostBannerIdRestoreRequest(c.Server, id, params)
 	if err != nil {
 		return nil, err
 	}
@@ -270,47 +583,223 @@ func (c *Client) GetUserBanner(ctx context.Context, params *GetUserBannerParams,
 	return c.Client.Do(req)
 }
 
-// NewGetBannerRequest generates requests for GetBanner
-func NewGetBannerRequest(server string, params *GetBannerParams) (*http.Request, error) {
-	var err error
-
-	serverURL, err := url.Parse(server)
+func (c *Client) DeleteFeatureIdSchema(ctx context.Context, id int, params *DeleteFeatureIdSchemaParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewDeleteFeatureIdSchemaRequest(c.Server, id, params)
 	if err != nil {
 		return nil, err
 	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
 
-	operationPath := fmt.Sprintf("/banner")
-	if operationPath[0] == '/' {
-		operationPath = "." + operationPath
+func (c *Client) GetFeatureIdSchema(ctx context.Context, id int, params *GetFeatureIdSchemaParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetFeatureIdSchemaRequest(c.Server, id, params)
+	if err != nil {
+		return nil, err
 	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
 
-	queryURL, err := serverURL.Parse(operationPath)
+func (c *Client) PutFeatureIdSchemaWithBody(ctx context.Context, id int, params *PutFeatureIdSchemaParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPutFeatureIdSchemaRequestWithBody(c.Server, id, params, contentType, body)
 	if err != nil {
 		return nil, err
 	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
 
-	if params != nil {
-		queryValues := queryURL.Query()
+func (c *Client) PutFeatureIdSchema(ctx context.Context, id int, params *PutFeatureIdSchemaParams, body PutFeatureIdSchemaJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPutFeatureIdSchemaRequest(c.Server, id, params, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
 
-		if params.FeatureId != nil {
+func (c *Client) GetJobsId(ctx context.Context, id int, params *GetJobsIdParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetJobsIdRequest(c.Server, id, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
 
-			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "feature_id", runtime.ParamLocationQuery, *params.FeatureId); err != nil {
-				return nil, err
-			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
-				return nil, err
-			} else {
-				for k, v := range parsed {
-					for _, v2 := range v {
-						queryValues.Add(k, v2)
-					}
-				}
-			}
+func (c *Client) GetTokens(ctx context.Context, params *GetTokensParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetTokensRequest(c.Server, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
 
-		}
+func (c *Client) PostTokensWithBody(ctx context.Context, params *PostTokensParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostTokensRequestWithBody(c.Server, params, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
 
-		if params.TagId != nil {
+func (c *Client) PostTokens(ctx context.Context, params *PostTokensParams, body PostTokensJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostTokensRequest(c.Server, params, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
 
-			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "tag_id", runtime.ParamLocationQuery, *params.TagId); err != nil {
+func (c *Client) DeleteTokensId(ctx context.Context, id string, params *DeleteTokensIdParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewDeleteTokensIdRequest(c.Server, id, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetUserBanner(ctx context.Context, params *GetUserBannerParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetUserBannerRequest(c.Server, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PostUserBannerBatchWithBody(ctx context.Context, params *PostUserBannerBatchParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostUserBannerBatchRequestWithBody(c.Server, params, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PostUserBannerBatch(ctx context.Context, params *PostUserBannerBatchParams, body PostUserBannerBatchJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostUserBannerBatchRequest(c.Server, params, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+// NewGetAuditRequest generates requests for GetAudit
+func NewGetAuditRequest(server string, params *GetAuditParams) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/audit")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.BannerId != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "banner_id", runtime.ParamLocationQuery, *params.BannerId); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Actor != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "actor", runtime.ParamLocationQuery, *params.Actor); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.From != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "from", runtime.ParamLocationQuery, *params.From); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.To != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "to", runtime.ParamLocationQuery, *params.To); err != nil {
 				return nil, err
 			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
 				return nil, err
@@ -382,19 +871,8 @@ func NewGetBannerRequest(server string, params *GetBannerParams) (*http.Request,
 	return req, nil
 }
 
-// NewPostBannerRequest calls the generic PostBanner builder with application/json body
-func NewPostBannerRequest(server string, params *PostBannerParams, body PostBannerJSONRequestBody) (*http.Request, error) {
-	var bodyReader io.Reader
-	buf, err := json.Marshal(body)
-	if err != nil {
-		return nil, err
-	}
-	bodyReader = bytes.NewReader(buf)
-	return NewPostBannerRequestWithBody(server, params, "application/json", bodyReader)
-}
-
-// NewPostBannerRequestWithBody generates requests for PostBanner with any type of body
-func NewPostBannerRequestWithBody(server string, params *PostBannerParams, contentType string, body io.Reader) (*http.Request, error) {
+// NewDeleteBannerRequest generates requests for DeleteBanner
+func NewDeleteBannerRequest(server string, params *DeleteBannerParams) (*http.Request, error) {
 	var err error
 
 	serverURL, err := url.Parse(server)
@@ -412,55 +890,42 @@ func NewPostBannerRequestWithBody(server string, params *PostBannerParams, conte
 		return nil, err
 	}
 
-	req, err := http.NewRequest("POST", queryURL.String(), body)
-	if err != nil {
-		return nil, err
-	}
-
-	req.Header.Add("Content-Type", contentType)
-
 	if params != nil {
+		queryValues := queryURL.Query()
 
-		if params.Token != nil {
-			var headerParam0 string
+		if params.FeatureId != nil {
 
-			headerParam0, err = runtime.StyleParamWithLocation("simple", false, "token", runtime.ParamLocationHeader, *params.Token)
-			if err != nil {
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "feature_id", runtime.ParamLocationQuery, *params.FeatureId); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
 				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
 			}
 
-			req.Header.Set("token", headerParam0)
 		}
 
-	}
-
-	return req, nil
-}
-
-// NewDeleteBannerIdRequest generates requests for DeleteBannerId
-func NewDeleteBannerIdRequest(server string, id int, params *DeleteBannerIdParams) (*http.Request, error) {
-	var err error
-
-	var pathParam0 string
-
-	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
-	if err != nil {
-		return nil, err
-	}
+		if params.TagId != nil {
 
-	serverURL, err := url.Parse(server)
-	if err != nil {
-		return nil, err
-	}
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "tag_id", runtime.ParamLocationQuery, *params.TagId); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
 
-	operationPath := fmt.Sprintf("/banner/%s", pathParam0)
-	if operationPath[0] == '/' {
-		operationPath = "." + operationPath
-	}
+		}
 
-	queryURL, err := serverURL.Parse(operationPath)
-	if err != nil {
-		return nil, err
+		queryURL.RawQuery = queryValues.Encode()
 	}
 
 	req, err := http.NewRequest("DELETE", queryURL.String(), nil)
@@ -486,34 +951,16 @@ func NewDeleteBannerIdRequest(server string, id int, params *DeleteBannerIdParam
 	return req, nil
 }
 
-// NewPatchBannerIdRequest calls the generic PatchBannerId builder with application/json body
-func NewPatchBannerIdRequest(server string, id int, params *PatchBannerIdParams, body PatchBannerIdJSONRequestBody) (*http.Request, error) {
-	var bodyReader io.Reader
-	buf, err := json.Marshal(body)
-	if err != nil {
-		return nil, err
-	}
-	bodyReader = bytes.NewReader(buf)
-	return NewPatchBannerIdRequestWithBody(server, id, params, "application/json", bodyReader)
-}
-
-// NewPatchBannerIdRequestWithBody generates requests for PatchBannerId with any type of body
-func NewPatchBannerIdRequestWithBody(server string, id int, params *PatchBannerIdParams, contentType string, body io.Reader) (*http.Request, error) {
+// NewGetBannerRequest generates requests for GetBanner
+func NewGetBannerRequest(server string, params *GetBannerParams) (*http.Request, error) {
 	var err error
 
-	var pathParam0 string
-
-	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
-	if err != nil {
-		return nil, err
-	}
-
 	serverURL, err := url.Parse(server)
 	if err != nil {
 		return nil, err
 	}
 
-	operationPath := fmt.Sprintf("/banner/%s", pathParam0)
+	operationPath := fmt.Sprintf("/banner")
 	if operationPath[0] == '/' {
 		operationPath = "." + operationPath
 	}
@@ -523,80 +970,92 @@ func NewPatchBannerIdRequestWithBody(server string, id int, params *PatchBannerI
 		return nil, err
 	}
 
-	req, err := http.NewRequest("PATCH", queryURL.String(), body)
-	if err != nil {
-		return nil, err
-	}
-
-	req.Header.Add("Content-Type", contentType)
-
 	if params != nil {
+		queryValues := queryURL.Query()
 
-		if params.Token != nil {
-			var headerParam0 string
+		if params.FeatureId != nil {
 
-			headerParam0, err = runtime.StyleParamWithLocation("simple", false, "token", runtime.ParamLocationHeader, *params.Token)
-			if err != nil {
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "feature_id", runtime.ParamLocationQuery, *params.FeatureId); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
 				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
 			}
 
-			req.Header.Set("token", headerParam0)
 		}
 
-	}
+		if params.TagId != nil {
 
-	return req, nil
-}
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "tag_id", runtime.ParamLocationQuery, *params.TagId); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
 
-// NewGetUserBannerRequest generates requests for GetUserBanner
-func NewGetUserBannerRequest(server string, params *GetUserBannerParams) (*http.Request, error) {
-	var err error
+		}
 
-	serverURL, err := url.Parse(server)
-	if err != nil {
-		return nil, err
-	}
+		if params.Limit != nil {
 
-	operationPath := fmt.Sprintf("/user_banner")
-	if operationPath[0] == '/' {
-		operationPath = "." + operationPath
-	}
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "limit", runtime.ParamLocationQuery, *params.Limit); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
 
-	queryURL, err := serverURL.Parse(operationPath)
-	if err != nil {
-		return nil, err
-	}
+		}
 
-	if params != nil {
-		queryValues := queryURL.Query()
+		if params.Offset != nil {
 
-		if queryFrag, err := runtime.StyleParamWithLocation("form", true, "tag_id", runtime.ParamLocationQuery, params.TagId); err != nil {
-			return nil, err
-		} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
-			return nil, err
-		} else {
-			for k, v := range parsed {
-				for _, v2 := range v {
-					queryValues.Add(k, v2)
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "offset", runtime.ParamLocationQuery, *params.Offset); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
 				}
 			}
+
 		}
 
-		if queryFrag, err := runtime.StyleParamWithLocation("form", true, "feature_id", runtime.ParamLocationQuery, params.FeatureId); err != nil {
-			return nil, err
-		} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
-			return nil, err
-		} else {
-			for k, v := range parsed {
-				for _, v2 := range v {
-					queryValues.Add(k, v2)
+		if params.Cursor != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "cursor", runtime.ParamLocationQuery, *params.Cursor); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
 				}
 			}
+
 		}
 
-		if params.UseLastRevision != nil {
+		if params.Deleted != nil {
 
-			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "use_last_revision", runtime.ParamLocationQuery, *params.UseLastRevision); err != nil {
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "deleted", runtime.ParamLocationQuery, *params.Deleted); err != nil {
 				return nil, err
 			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
 				return nil, err
@@ -636,568 +1095,3802 @@ func NewGetUserBannerRequest(server string, params *GetUserBannerParams) (*http.
 	return req, nil
 }
 
-func (c *Client) applyEditors(ctx context.Context, req *http.Request, additionalEditors []RequestEditorFn) error {
-	for _, r := range c.RequestEditors {
-		if err := r(ctx, req); err != nil {
-			return err
-		}
-	}
-	for _, r := range additionalEditors {
-		if err := r(ctx, req); err != nil {
-			return err
-		}
+// NewPostBannerRequest calls the generic PostBanner builder with application/json body
+func NewPostBannerRequest(server string, params *PostBannerParams, body PostBannerJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
 	}
-	return nil
+	bodyReader = bytes.NewReader(buf)
+	return NewPostBannerRequestWithBody(server, params, "application/json", bodyReader)
 }
 
-// ClientWithResponses builds on ClientInterface to offer response payloads
-type ClientWithResponses struct {
-	ClientInterface
-}
+// NewPostBannerRequestWithBody generates requests for PostBanner with any type of body
+func NewPostBannerRequestWithBody(server string, params *PostBannerParams, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
 
-// NewClientWithResponses creates a new ClientWithResponses, which wraps
-// Client with return type handling
-func NewClientWithResponses(server string, opts ...ClientOption) (*ClientWithResponses, error) {
-	client, err := NewClient(server, opts...)
+	serverURL, err := url.Parse(server)
 	if err != nil {
 		return nil, err
 	}
-	return &ClientWithResponses{client}, nil
-}
 
-// WithBaseURL overrides the baseURL.
-func WithBaseURL(baseURL string) ClientOption {
-	return func(c *Client) error {
-		newBaseURL, err := url.Parse(baseURL)
-		if err != nil {
-			return err
-		}
-		c.Server = newBaseURL.String()
-		return nil
+	operationPath := fmt.Sprintf("/banner")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
 	}
-}
 
-// ClientWithResponsesInterface is the interface specification for the client with responses above.
-type ClientWithResponsesInterface interface {
-	// GetBannerWithResponse request
-	GetBannerWithResponse(ctx context.Context, params *GetBannerParams, reqEditors ...RequestEditorFn) (*GetBannerResponse, error)
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
 
-	// PostBannerWithBodyWithResponse request with any body
-	PostBannerWithBodyWithResponse(ctx context.Context, params *PostBannerParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostBannerResponse, error)
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
 
-	PostBannerWithResponse(ctx context.Context, params *PostBannerParams, body PostBannerJSONRequestBody, reqEditors ...RequestEditorFn) (*PostBannerResponse, error)
+	req.Header.Add("Content-Type", contentType)
 
-	// DeleteBannerIdWithResponse request
-	DeleteBannerIdWithResponse(ctx context.Context, id int, params *DeleteBannerIdParams, reqEditors ...RequestEditorFn) (*DeleteBannerIdResponse, error)
+	if params != nil {
 
-	// PatchBannerIdWithBodyWithResponse request with any body
-	PatchBannerIdWithBodyWithResponse(ctx context.Context, id int, params *PatchBannerIdParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PatchBannerIdResponse, error)
+		if params.Token != nil {
+			var headerParam0 string
 
-	PatchBannerIdWithResponse(ctx context.Context, id int, params *PatchBannerIdParams, body PatchBannerIdJSONRequestBody, reqEditors ...RequestEditorFn) (*PatchBannerIdResponse, error)
+			headerParam0, err = runtime.StyleParamWithLocation("simple", false, "token", runtime.ParamLocationHeader, *params.Token)
+			if err != nil {
+				return nil, err
+			}
 
-	// GetUserBannerWithResponse request
-	GetUserBannerWithResponse(ctx context.Context, params *GetUserBannerParams, reqEditors ...RequestEditorFn) (*GetUserBannerResponse, error)
+			req.Header.Set("token", headerParam0)
+		}
+
+	}
+
+	return req, nil
 }
 
-type GetBannerResponse struct {
-	Body         []byte
-	HTTPResponse *http.Response
-	JSON200      *[]struct {
-		// BannerId Идентификатор баннера
-		BannerId *int `json:"banner_id,omitempty"`
+// NewGetBannerExportRequest generates requests for GetBannerExport
+func NewGetBannerExportRequest(server string, params *GetBannerExportParams) (*http.Request, error) {
+	var err error
 
-		// Content Содержимое баннера
-		Content *map[string]interface{} `json:"content,omitempty"`
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
 
-		// CreatedAt Дата создания баннера
-		CreatedAt *time.Time `json:"created_at,omitempty"`
+	operationPath := fmt.Sprintf("/banner/export")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
 
-		// FeatureId Идентификатор фичи
-		FeatureId *int `json:"feature_id,omitempty"`
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
 
-		// IsActive Флаг активности баннера
-		IsActive *bool `json:"is_active,omitempty"`
+	if params != nil {
+		queryValues := queryURL.Query()
 
-		// TagIds Идентификаторы тэгов
-		TagIds *[]int `json:"tag_ids,omitempty"`
+		if params.Format != nil {
 
-		// UpdatedAt Дата обновления баннера
-		UpdatedAt *time.Time `json:"updated_at,omitempty"`
-	}
-	JSON500 *struct {
-		Error *string `json:"error,omitempty"`
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "format", runtime.ParamLocationQuery, *params.Format); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
 	}
-}
 
-// Status returns HTTPResponse.Status
-func (r GetBannerResponse) Status() string {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.Status
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
 	}
-	return http.StatusText(0)
-}
 
-// StatusCode returns HTTPResponse.StatusCode
-func (r GetBannerResponse) StatusCode() int {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.StatusCode
+	if params != nil {
+
+		if params.Token != nil {
+			var headerParam0 string
+
+			headerParam0, err = runtime.StyleParamWithLocation("simple", false, "token", runtime.ParamLocationHeader, *params.Token)
+			if err != nil {
+				return nil, err
+			}
+
+			req.Header.Set("token", headerParam0)
+		}
+
 	}
-	return 0
+
+	return req, nil
 }
 
-type PostBannerResponse struct {
-	Body         []byte
-	HTTPResponse *http.Response
-	JSON201      *struct {
-		// BannerId Идентификатор созданного баннера
-		BannerId *int `json:"banner_id,omitempty"`
-	}
-	JSON400 *struct {
-		Error *string `json:"error,omitempty"`
-	}
-	JSON500 *struct {
-		Error *string `json:"error,omitempty"`
+// NewPostBannerImportRequestWithBody generates requests for PostBannerImport with any type of body
+func NewPostBannerImportRequestWithBody(server string, params *PostBannerImportParams, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
 	}
-}
 
-// Status returns HTTPResponse.Status
-func (r PostBannerResponse) Status() string {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.Status
+	operationPath := fmt.Sprintf("/banner/import")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
 	}
-	return http.StatusText(0)
-}
 
-// StatusCode returns HTTPResponse.StatusCode
-func (r PostBannerResponse) StatusCode() int {
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.Format != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "format", runtime.ParamLocationQuery, *params.Format); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Mode != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "mode", runtime.ParamLocationQuery, *params.Mode); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	if params != nil {
+
+		if params.Token != nil {
+			var headerParam0 string
+
+			headerParam0, err = runtime.StyleParamWithLocation("simple", false, "token", runtime.ParamLocationHeader, *params.Token)
+			if err != nil {
+				return nil, err
+			}
+
+			req.Header.Set("token", headerParam0)
+		}
+
+	}
+
+	return req, nil
+}
+
+// NewGetBannerVersionsIdRequest generates requests for GetBannerVersionsId
+func NewGetBannerVersionsIdRequest(server string, id int, params *GetBannerVersionsIdParams) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/banner/versions/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.Limit != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "limit", runtime.ParamLocationQuery, *params.Limit); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+
+		if params.Token != nil {
+			var headerParam0 string
+
+			headerParam0, err = runtime.StyleParamWithLocation("simple", false, "token", runtime.ParamLocationHeader, *params.Token)
+			if err != nil {
+				return nil, err
+			}
+
+			req.Header.Set("token", headerParam0)
+		}
+
+	}
+
+	return req, nil
+}
+
+// NewPutBannerVersionsIdActivateRequest generates requests for PutBannerVersionsIdActivate
+func NewPutBannerVersionsIdActivateRequest(server string, id int, params *PutBannerVersionsIdActivateParams) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/banner/versions/%s/activate", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if queryFrag, err := runtime.StyleParamWithLocation("form", true, "version", runtime.ParamLocationQuery, params.Version); err != nil {
+			return nil, err
+		} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+			return nil, err
+		} else {
+			for k, v := range parsed {
+				for _, v2 := range v {
+					queryValues.Add(k, v2)
+				}
+			}
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("PUT", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+
+		if params.Token != nil {
+			var headerParam0 string
+
+			headerParam0, err = runtime.StyleParamWithLocation("simple", false, "token", runtime.ParamLocationHeader, *params.Token)
+			if err != nil {
+				return nil, err
+			}
+
+			req.Header.Set("token", headerParam0)
+		}
+
+	}
+
+	return req, nil
+}
+
+// NewDeleteBannerIdRequest generates requests for DeleteBannerId
+func NewDeleteBannerIdRequest(server string, id int, params *DeleteBannerIdParams) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/banner/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("DELETE", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+
+		if params.Token != nil {
+			var headerParam0 string
+
+			headerParam0, err = runtime.StyleParamWithLocation("simple", false, "token", runtime.ParamLocationHeader, *params.Token)
+			if err != nil {
+				return nil, err
+			}
+
+			req.Header.Set("token", headerParam0)
+		}
+
+	}
+
+	return req, nil
+}
+
+// NewPatchBannerIdRequest calls the generic PatchBannerId builder with application/json body
+func NewPatchBannerIdRequest(server string, id int, params *PatchBannerIdParams, body PatchBannerIdJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewPatchBannerIdRequestWithBody(server, id, params, "application/json", bodyReader)
+}
+
+// NewPatchBannerIdRequestWithBody generates requests for PatchBannerId with any type of body
+func NewPatchBannerIdRequestWithBody(server string, id int, params *PatchBannerIdParams, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/banner/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("PATCH", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	if params != nil {
+
+		if params.Token != nil {
+			var headerParam0 string
+
+			headerParam0, err = runtime.StyleParamWithLocation("simple", false, "token", runtime.ParamLocationHeader, *params.Token)
+			if err != nil {
+				return nil, err
+			}
+
+			req.Header.Set("token", headerParam0)
+		}
+
+	}
+
+	return req, nil
+}
+
+// NewPostBannerIdRestoreRequest generates requests for PostBannerIdRestore
+func NewPostBannerIdRestoreRequest(server string, id int, params *PostBannerIdRestoreParams) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/banner/%s/restore", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+
+		if params.Token != nil {
+			var headerParam0 string
+
+			headerParam0, err = runtime.StyleParamWithLocation("simple", false, "token", runtime.ParamLocationHeader, *params.Token)
+			if err != nil {
+				return nil, err
+			}
+
+			req.Header.Set("token", headerParam0)
+		}
+
+	}
+
+	return req, nil
+}
+
+// NewDeleteFeatureIdSchemaRequest generates requests for DeleteFeatureIdSchema
+func NewDeleteFeatureIdSchemaRequest(server string, id int, params *DeleteFeatureIdSchemaParams) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/feature/%s/schema", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("DELETE", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+
+		if params.Token != nil {
+			var headerParam0 string
+
+			headerParam0, err = runtime.StyleParamWithLocation("simple", false, "token", runtime.ParamLocationHeader, *params.Token)
+			if err != nil {
+				return nil, err
+			}
+
+			req.Header.Set("token", headerParam0)
+		}
+
+	}
+
+	return req, nil
+}
+
+// NewGetFeatureIdSchemaRequest generates requests for GetFeatureIdSchema
+func NewGetFeatureIdSchemaRequest(server string, id int, params *GetFeatureIdSchemaParams) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/feature/%s/schema", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+
+		if params.Token != nil {
+			var headerParam0 string
+
+			headerParam0, err = runtime.StyleParamWithLocation("simple", false, "token", runtime.ParamLocationHeader, *params.Token)
+			if err != nil {
+				return nil, err
+			}
+
+			req.Header.Set("token", headerParam0)
+		}
+
+	}
+
+	return req, nil
+}
+
+// NewPutFeatureIdSchemaRequest calls the generic PutFeatureIdSchema builder with application/json body
+func NewPutFeatureIdSchemaRequest(server string, id int, params *PutFeatureIdSchemaParams, body PutFeatureIdSchemaJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewPutFeatureIdSchemaRequestWithBody(server, id, params, "application/json", bodyReader)
+}
+
+// NewPutFeatureIdSchemaRequestWithBody generates requests for PutFeatureIdSchema with any type of body
+func NewPutFeatureIdSchemaRequestWithBody(server string, id int, params *PutFeatureIdSchemaParams, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/feature/%s/schema", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("PUT", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	if params != nil {
+
+		if params.Token != nil {
+			var headerParam0 string
+
+			headerParam0, err = runtime.StyleParamWithLocation("simple", false, "token", runtime.ParamLocationHeader, *params.Token)
+			if err != nil {
+				return nil, err
+			}
+
+			req.Header.Set("token", headerParam0)
+		}
+
+	}
+
+	return req, nil
+}
+
+// NewGetJobsIdRequest generates requests for GetJobsId
+func NewGetJobsIdRequest(server string, id int, params *GetJobsIdParams) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/jobs/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+
+		if params.Token != nil {
+			var headerParam0 string
+
+			headerParam0, err = runtime.StyleParamWithLocation("simple", false, "token", runtime.ParamLocationHeader, *params.Token)
+			if err != nil {
+				return nil, err
+			}
+
+			req.Header.Set("token", headerParam0)
+		}
+
+	}
+
+	return req, nil
+}
+
+// NewGetTokensRequest generates requests for GetTokens
+func NewGetTokensRequest(server string, params *GetTokensParams) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/tokens")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+
+		if params.Token != nil {
+			var headerParam0 string
+
+			headerParam0, err = runtime.StyleParamWithLocation("simple", false, "token", runtime.ParamLocationHeader, *params.Token)
+			if err != nil {
+				return nil, err
+			}
+
+			req.Header.Set("token", headerParam0)
+		}
+
+	}
+
+	return req, nil
+}
+
+// NewPostTokensRequest calls the generic PostTokens builder with application/json body
+func NewPostTokensRequest(server string, params *PostTokensParams, body PostTokensJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewPostTokensRequestWithBody(server, params, "application/json", bodyReader)
+}
+
+// NewPostTokensRequestWithBody generates requests for PostTokens with any type of body
+func NewPostTokensRequestWithBody(server string, params *PostTokensParams, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/tokens")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	if params != nil {
+
+		if params.Token != nil {
+			var headerParam0 string
+
+			headerParam0, err = runtime.StyleParamWithLocation("simple", false, "token", runtime.ParamLocationHeader, *params.Token)
+			if err != nil {
+				return nil, err
+			}
+
+			req.Header.Set("token", headerParam0)
+		}
+
+	}
+
+	return req, nil
+}
+
+// NewDeleteTokensIdRequest generates requests for DeleteTokensId
+func NewDeleteTokensIdRequest(server string, id string, params *DeleteTokensIdParams) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/tokens/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("DELETE", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+
+		if params.Token != nil {
+			var headerParam0 string
+
+			headerParam0, err = runtime.StyleParamWithLocation("simple", false, "token", runtime.ParamLocationHeader, *params.Token)
+			if err != nil {
+				return nil, err
+			}
+
+			req.Header.Set("token", headerParam0)
+		}
+
+	}
+
+	return req, nil
+}
+
+// NewGetUserBannerRequest generates requests for GetUserBanner
+func NewGetUserBannerRequest(server string, params *GetUserBannerParams) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/user_banner")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if queryFrag, err := runtime.StyleParamWithLocation("form", true, "tag_id", runtime.ParamLocationQuery, params.TagId); err != nil {
+			return nil, err
+		} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+			return nil, err
+		} else {
+			for k, v := range parsed {
+				for _, v2 := range v {
+					queryValues.Add(k, v2)
+				}
+			}
+		}
+
+		if queryFrag, err := runtime.StyleParamWithLocation("form", true, "feature_id", runtime.ParamLocationQuery, params.FeatureId); err != nil {
+			return nil, err
+		} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+			return nil, err
+		} else {
+			for k, v := range parsed {
+				for _, v2 := range v {
+					queryValues.Add(k, v2)
+				}
+			}
+		}
+
+		if params.UseLastRevision != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "use_last_revision", runtime.ParamLocationQuery, *params.UseLastRevision); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+
+		if params.Token != nil {
+			var headerParam0 string
+
+			headerParam0, err = runtime.StyleParamWithLocation("simple", false, "token", runtime.ParamLocationHeader, *params.Token)
+			if err != nil {
+				return nil, err
+			}
+
+			req.Header.Set("token", headerParam0)
+		}
+
+	}
+
+	return req, nil
+}
+
+// NewPostUserBannerBatchRequest calls the generic PostUserBannerBatch builder with application/json body
+func NewPostUserBannerBatchRequest(server string, params *PostUserBannerBatchParams, body PostUserBannerBatchJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewPostUserBannerBatchRequestWithBody(server, params, "application/json", bodyReader)
+}
+
+// NewPostUserBannerBatchRequestWithBody generates requests for PostUserBannerBatch with any type of body
+func NewPostUserBannerBatchRequestWithBody(server string, params *PostUserBannerBatchParams, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/user_banner/batch")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	if params != nil {
+
+		if params.Token != nil {
+			var headerParam0 string
+
+			headerParam0, err = runtime.StyleParamWithLocation("simple", false, "token", runtime.ParamLocationHeader, *params.Token)
+			if err != nil {
+				return nil, err
+			}
+
+			req.Header.Set("token", headerParam0)
+		}
+
+	}
+
+	return req, nil
+}
+
+func (c *Client) applyEditors(ctx context.Context, req *http.Request, additionalEditors []RequestEditorFn) error {
+	for _, r := range c.RequestEditors {
+		if err := r(ctx, req); err != nil {
+			return err
+		}
+	}
+	for _, r := range additionalEditors {
+		if err := r(ctx, req); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ClientWithResponses builds on ClientInterface to offer response payloads
+type ClientWithResponses struct {
+	ClientInterface
+}
+
+// NewClientWithResponses creates a new ClientWithResponses, which wraps
+// Client with return type handling
+func NewClientWithResponses(server string, opts ...ClientOption) (*ClientWithResponses, error) {
+	client, err := NewClient(server, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &ClientWithResponses{client}, nil
+}
+
+// WithBaseURL overrides the baseURL.
+func WithBaseURL(baseURL string) ClientOption {
+	return func(c *Client) error {
+		newBaseURL, err := url.Parse(baseURL)
+		if err != nil {
+			return err
+		}
+		c.Server = newBaseURL.String()
+		return nil
+	}
+}
+
+// ClientWithResponsesInterface is the interface specification for the client with responses above.
+type ClientWithResponsesInterface interface {
+	// GetAuditWithResponse request
+	GetAuditWithResponse(ctx context.Context, params *GetAuditParams, reqEditors ...RequestEditorFn) (*GetAuditResponse, error)
+
+	// DeleteBannerWithResponse request
+	DeleteBannerWithResponse(ctx context.Context, params *DeleteBannerParams, reqEditors ...RequestEditorFn) (*DeleteBannerResponse, error)
+
+	// GetBannerWithResponse request
+	GetBannerWithResponse(ctx context.Context, params *GetBannerParams, reqEditors ...RequestEditorFn) (*GetBannerResponse, error)
+
+	// PostBannerWithBodyWithResponse request with any body
+	PostBannerWithBodyWithResponse(ctx context.Context, params *PostBannerParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostBannerResponse, error)
+
+	PostBannerWithResponse(ctx context.Context, params *PostBannerParams, body PostBannerJSONRequestBody, reqEditors ...RequestEditorFn) (*PostBannerResponse, error)
+
+	// GetBannerExportWithResponse request
+	GetBannerExportWithResponse(ctx context.Context, params *GetBannerExportParams, reqEditors ...RequestEditorFn) (*GetBannerExportResponse, error)
+
+	// PostBannerImportWithBodyWithResponse request with any body
+	PostBannerImportWithBodyWithResponse(ctx context.Context, params *PostBannerImportParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostBannerImportResponse, error)
+
+	// GetBannerVersionsIdWithResponse request
+	GetBannerVersionsIdWithResponse(ctx context.Context, id int, params *GetBannerVersionsIdParams, reqEditors ...RequestEditorFn) (*GetBannerVersionsIdResponse, error)
+
+	// PutBannerVersionsIdActivateWithResponse request
+	PutBannerVersionsIdActivateWithResponse(ctx context.Context, id int, params *PutBannerVersionsIdActivateParams, reqEditors ...RequestEditorFn) (*PutBannerVersionsIdActivateResponse, error)
+
+	// DeleteBannerIdWithResponse request
+	DeleteBannerIdWithResponse(ctx context.Context, id int, params *DeleteBannerIdParams, reqEditors ...RequestEditorFn) (*DeleteBannerIdResponse, error)
+
+	// PatchBannerIdWithBodyWithResponse request with any body
+	PatchBannerIdWithBodyWithResponse(ctx context.Context, id int, params *PatchBannerIdParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PatchBannerIdResponse, error)
+
+	PatchBannerIdWithResponse(ctx context.Context, id int, params *PatchBannerIdParams, body PatchBannerIdJSONRequestBody, reqEditors ...RequestEditorFn) (*PatchBannerIdResponse, error)
+
+	// PostBannerIdRestoreWithResponse request
+	PostBannerIdRestoreWithResponse(ctx context.Context, id int, params *PostBannerIdRestoreParams, reqEditors ...RequestEditorFn) (*PostBannerIdRestoreResponse, error)
+
+	// DeleteFeatureIdSchemaWithResponse request
+	DeleteFeatureIdSchemaWithResponse(ctx context.Context, id int, params *DeleteFeatureIdSchemaParams, reqEditors ...RequestEditorFn) (*DeleteFeatureIdSchemaResponse, error)
+
+	// GetFeatureIdSchemaWithResponse request
+	GetFeatureIdSchemaWithResponse(ctx context.Context, id int, params *GetFeatureIdSchemaParams, reqEditors ...RequestEditorFn) (*GetFeatureIdSchemaResponse, error)
+
+	// PutFeatureIdSchemaWithBodyWithResponse request with any body
+	PutFeatureIdSchemaWithBodyWithResponse(ctx context.Context, id int, params *PutFeatureIdSchemaParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PutFeatureIdSchemaResponse, error)
+
+	PutFeatureIdSchemaWithResponse(ctx context.Context, id int, params *PutFeatureIdSchemaParams, body PutFeatureIdSchemaJSONRequestBody, reqEditors ...RequestEditorFn) (*PutFeatureIdSchemaResponse, error)
+
+	// GetJobsIdWithResponse request
+	GetJobsIdWithResponse(ctx context.Context, id int, params *GetJobsIdParams, reqEditors ...RequestEditorFn) (*GetJobsIdResponse, error)
+
+	// GetTokensWithResponse request
+	GetTokensWithResponse(ctx context.Context, params *GetTokensParams, reqEditors ...RequestEditorFn) (*GetTokensResponse, error)
+
+	// PostTokensWithBodyWithResponse request with any body
+	PostTokensWithBodyWithResponse(ctx context.Context, params *PostTokensParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostTokensResponse, error)
+
+	PostTokensWithResponse(ctx context.Context, params *PostTokensParams, body PostTokensJSONRequestBody, reqEditors ...RequestEditorFn) (*PostTokensResponse, error)
+
+	// DeleteTokensIdWithResponse request
+	DeleteTokensIdWithResponse(ctx context.Context, id string, params *DeleteTokensIdParams, reqEditors ...RequestEditorFn) (*DeleteTokensIdResponse, error)
+
+	// GetUserBannerWithResponse request
+	GetUserBannerWithResponse(ctx context.Context, params *GetUserBannerParams, reqEditors ...RequestEditorFn) (*GetUserBannerResponse, error)
+
+	// PostUserBannerBatchWithBodyWithResponse request with any body
+	PostUserBannerBatchWithBodyWithResponse(ctx context.Context, params *PostUserBannerBatchParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostUserBannerBatchResponse, error)
+
+	PostUserBannerBatchWithResponse(ctx context.Context, params *PostUserBannerBatchParams, body PostUserBannerBatchJSONRequestBody, reqEditors ...RequestEditorFn) (*PostUserBannerBatchResponse, error)
+}
+
+type GetAuditResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *[]struct {
+		// Action Действие (banner.create, banner.update, banner.delete, banner.restore, banner.rollback, banner.purge)
+		Action *string `json:"action,omitempty"`
+
+		// ActorRole Роль токена
+		ActorRole *string `json:"actor_role,omitempty"`
+
+		// ActorTokenId Идентификатор токена, выполнившего изменение
+		ActorTokenId *string `json:"actor_token_id,omitempty"`
+
+		// BannerId Идентификатор баннера
+		BannerId *int `json:"banner_id,omitempty"`
+
+		// CreatedAt Время изменения
+		CreatedAt *time.Time `json:"created_at,omitempty"`
+
+		// Diff Изменённые поля баннера со значениями до (before) и после (after) изменения
+		Diff *map[string]interface{} `json:"diff,omitempty"`
+
+		// Id Идентификатор события
+		Id *int `json:"id,omitempty"`
+
+		// RequestId Идентификатор запроса
+		RequestId *string `json:"request_id,omitempty"`
+	}
+	JSON400 *struct {
+		Error *string `json:"error,omitempty"`
+	}
+	JSON500 *struct {
+		Error *string `json:"error,omitempty"`
+	}
+}
+
+// Status returns HTTPResponse.Status
+func (r GetAuditResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetAuditResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type DeleteBannerResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON202      *struct {
+		// JobId Идентификатор задачи удаления
+		JobId *int `json:"job_id,omitempty"`
+	}
+	JSON400 *struct {
+		Error *string `json:"error,omitempty"`
+	}
+	JSON500 *struct {
+		Error *string `json:"error,omitempty"`
+	}
+}
+
+// Status returns HTTPResponse.Status
+func (r DeleteBannerResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r DeleteBannerResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetBannerResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *[]struct {
+		// ActiveFrom Начало периода показа баннера
+		ActiveFrom *time.Time `json:"active_from"`
+
+		// ActiveUntil Окончание периода показа баннера
+		ActiveUntil *time.Time `json:"active_until"`
+
+		// BannerId Идентификатор баннера
+		BannerId *int `json:"banner_id,omitempty"`
+
+		// Content Содержимое баннера
+		Content *map[string]interface{} `json:"content,omitempty"`
+
+		// CreatedAt Дата создания баннера
+		CreatedAt *time.Time `json:"created_at,omitempty"`
+
+		// DeletedAt Дата удаления баннера, только для баннеров из корзины
+		DeletedAt *time.Time `json:"deleted_at,omitempty"`
+
+		// FeatureId Идентификатор фичи
+		FeatureId *int `json:"feature_id,omitempty"`
+
+		// IsActive Флаг активности баннера
+		IsActive *bool `json:"is_active,omitempty"`
+
+		// TagIds Идентификаторы тэгов
+		TagIds *[]int `json:"tag_ids,omitempty"`
+
+		// UpdatedAt Дата обновления баннера
+		UpdatedAt *time.Time `json:"updated_at,omitempty"`
+	}
+	JSON400 *struct {
+		Error *string `json:"error,omitempty"`
+	}
+	JSON500 *struct {
+		Error *string `json:"error,omitempty"`
+	}
+}
+
+// Status returns HTTPResponse.Status
+func (r GetBannerResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetBannerResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PostBannerResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON201      *struct {
+		// BannerId Идентификатор созданного баннера
+		BannerId *int `json:"banner_id,omitempty"`
+	}
+	JSON400 *struct {
+		Error *string `json:"error,omitempty"`
+
+		// Violations Нарушения JSON Schema фичи для содержимого баннера
+		Violations *[]struct {
+			// Message Описание нарушения
+			Message *string `json:"message,omitempty"`
+
+			// Path JSON Pointer нарушившего схему значения
+			Path *string `json:"path,omitempty"`
+		} `json:"violations,omitempty"`
+	}
+	JSON500 *struct {
+		Error *string `json:"error,omitempty"`
+	}
+}
+
+// Status returns HTTPResponse.Status
+func (r PostBannerResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PostBannerResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetBannerExportResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON400      *struct {
+		Error *string `json:"error,omitempty"`
+	}
+	JSON500 *struct {
+		Error *string `json:"error,omitempty"`
+	}
+}
+
+// Status returns HTTPResponse.Status
+func (r GetBannerExportResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetBannerExportResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PostBannerImportResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *struct {
+		// Created Число созданных баннеров
+		Created *int `json:"created,omitempty"`
+
+		// Error Причина отмены импорта
+		Error *string `json:"error,omitempty"`
+		Lines *[]struct {
+			// BannerId Идентификатор созданного, обновлённого или конфликтующего баннера
+			BannerId *int `json:"banner_id,omitempty"`
+
+			// Error Описание ошибки
+			Error *string `json:"error,omitempty"`
+
+			// Line Номер строки во входных данных
+			Line *int `json:"line,omitempty"`
+
+			// Status Результат (created, updated, skipped, conflict или invalid)
+			Status *string `json:"status,omitempty"`
+		} `json:"lines,omitempty"`
+
+		// Mode Режим импорта
+		Mode *string `json:"mode,omitempty"`
+
+		// Skipped Число пропущенных строк
+		Skipped *int `json:"skipped,omitempty"`
+
+		// Updated Число обновлённых баннеров
+		Updated *int `json:"updated,omitempty"`
+	}
+	JSON400 *struct {
+		// Created Число созданных баннеров
+		Created *int `json:"created,omitempty"`
+
+		// Error Причина отмены импорта
+		Error *string `json:"error,omitempty"`
+		Lines *[]struct {
+			// BannerId Идентификатор созданного, обновлённого или конфликтующего баннера
+			BannerId *int `json:"banner_id,omitempty"`
+
+			// Error Описание ошибки
+			Error *string `json:"error,omitempty"`
+
+			// Line Номер строки во входных данных
+			Line *int `json:"line,omitempty"`
+
+			// Status Результат (created, updated, skipped, conflict или invalid)
+			Status *string `json:"status,omitempty"`
+		} `json:"lines,omitempty"`
+
+		// Mode Режим импорта
+		Mode *string `json:"mode,omitempty"`
+
+		// Skipped Число пропущенных строк
+		Skipped *int `json:"skipped,omitempty"`
+
+		// Updated Число обновлённых баннеров
+		Updated *int `json:"updated,omitempty"`
+	}
+	JSON409 *struct {
+		// Created Число созданных баннеров
+		Created *int `json:"created,omitempty"`
+
+		// Error Причина отмены импорта
+		Error *string `json:"error,omitempty"`
+		Lines *[]struct {
+			// BannerId Идентификатор созданного, обновлённого или конфликтующего баннера
+			BannerId *int `json:"banner_id,omitempty"`
+
+			// Error Описание ошибки
+			Error *string `json:"error,omitempty"`
+
+			// Line Номер строки во входных данных
+			Line *int `json:"line,omitempty"`
+
+			// Status Результат (created, updated, skipped, conflict или invalid)
+			Status *string `json:"status,omitempty"`
+		} `json:"lines,omitempty"`
+
+		// Mode Режим импорта
+		Mode *string `json:"mode,omitempty"`
+
+		// Skipped Число пропущенных строк
+		Skipped *int `json:"skipped,omitempty"`
+
+		// Updated Число обновлённых баннеров
+		Updated *int `json:"updated,omitempty"`
+	}
+	JSON500 *struct {
+		Error *string `json:"error,omitempty"`
+	}
+}
+
+// Status returns HTTPResponse.Status
+func (r PostBannerImportResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PostBannerImportResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetBannerVersionsIdResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *[]struct {
+		// ActiveFrom Начало периода показа баннера
+		ActiveFrom *time.Time `json:"active_from"`
+
+		// ActiveUntil Окончание периода показа баннера
+		ActiveUntil *time.Time `json:"active_until"`
+
+		// Content Содержимое баннера
+		Content *map[string]interface{} `json:"content,omitempty"`
+
+		// CreatedAt Дата создания версии
+		CreatedAt *time.Time `json:"created_at,omitempty"`
+
+		// FeatureId Идентификатор фичи
+		FeatureId *int `json:"feature_id,omitempty"`
+
+		// IsActive Флаг активности баннера
+		IsActive *bool `json:"is_active,omitempty"`
+
+		// TagIds Идентификаторы тэгов
+		TagIds *[]int `json:"tag_ids,omitempty"`
+
+		// Version Номер версии
+		Version *int `json:"version,omitempty"`
+	}
+	JSON500 *struct {
+		Error *string `json:"error,omitempty"`
+	}
+}
+
+// Status returns HTTPResponse.Status
+func (r GetBannerVersionsIdResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetBannerVersionsIdResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PutBannerVersionsIdActivateResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON400      *struct {
+		Error *string `json:"error,omitempty"`
+	}
+	JSON500 *struct {
+		Error *string `json:"error,omitempty"`
+	}
+}
+
+// Status returns HTTPResponse.Status
+func (r PutBannerVersionsIdActivateResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PutBannerVersionsIdActivateResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type DeleteBannerIdResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON400      *struct {
+		Error *string `json:"error,omitempty"`
+	}
+	JSON500 *struct {
+		Error *string `json:"error,omitempty"`
+	}
+}
+
+// Status returns HTTPResponse.Status
+func (r DeleteBannerIdResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r DeleteBannerIdResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PatchBannerIdResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON400      *struct {
+		Error *string `json:"error,omitempty"`
+
+		// Violations Нарушения JSON Schema фичи для содержимого баннера
+		Violations *[]struct {
+			// Message Описание нарушения
+			Message *string `json:"message,omitempty"`
+
+			// Path JSON Pointer нарушившего схему значения
+			Path *string `json:"path,omitempty"`
+		} `json:"violations,omitempty"`
+	}
+	JSON500 *struct {
+		Error *string `json:"error,omitempty"`
+	}
+}
+
+// Status returns HTTPResponse.Status
+func (r PatchBannerIdResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PatchBannerIdResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PostBannerIdRestoreResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON500      *struct {
+		Error *string `json:"error,omitempty"`
+	}
+}
+
+// Status returns HTTPResponse.Status
+func (r PostBannerIdRestoreResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PostBannerIdRestoreResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type DeleteFeatureIdSchemaResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON500      *struct {
+		Error *string `json:"error,omitempty"`
+	}
+}
+
+// Status returns HTTPResponse.Status
+func (r DeleteFeatureIdSchemaResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r DeleteFeatureIdSchemaResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetFeatureIdSchemaResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *map[string]interface{}
+	JSON500      *struct {
+		Error *string `json:"error,omitempty"`
+	}
+}
+
+// Status returns HTTPResponse.Status
+func (r GetFeatureIdSchemaResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetFeatureIdSchemaResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PutFeatureIdSchemaResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON400      *struct {
+		Error *string `json:"error,omitempty"`
+	}
+	JSON500 *struct {
+		Error *string `json:"error,omitempty"`
+	}
+}
+
+// Status returns HTTPResponse.Status
+func (r PutFeatureIdSchemaResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PutFeatureIdSchemaResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetJobsIdResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *struct {
+		// CreatedAt Дата создания задачи
+		CreatedAt *time.Time `json:"created_at,omitempty"`
+
+		// Deleted Количество удалённых баннеров
+		Deleted *int `json:"deleted,omitempty"`
+
+		// Error Текст ошибки для упавшей задачи
+		Error *string `json:"error,omitempty"`
+
+		// FeatureId Идентификатор фичи
+		FeatureId *int `json:"feature_id"`
+
+		// JobId Идентификатор задачи
+		JobId *int `json:"job_id,omitempty"`
+
+		// Status Статус задачи (pending, running, done, failed)
+		Status *string `json:"status,omitempty"`
+
+		// TagId Идентификатор тега
+		TagId *int `json:"tag_id"`
+
+		// UpdatedAt Дата обновления задачи
+		UpdatedAt *time.Time `json:"updated_at,omitempty"`
+	}
+	JSON500 *struct {
+		Error *string `json:"error,omitempty"`
+	}
+}
+
+// Status returns HTTPResponse.Status
+func (r GetJobsIdResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetJobsIdResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetTokensResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *[]struct {
+		// CreatedAt Дата выпуска токена
+		CreatedAt *time.Time `json:"created_at,omitempty"`
+
+		// ExpiresAt Дата истечения токена
+		ExpiresAt *time.Time `json:"expires_at"`
+
+		// Id Идентификатор токена
+		Id *string `json:"id,omitempty"`
+
+		// Revoked Флаг отзыва токена
+		Revoked *bool `json:"revoked,omitempty"`
+
+		// Role Роль владельца токена
+		Role *string `json:"role,omitempty"`
+	}
+	JSON500 *struct {
+		Error *string `json:"error,omitempty"`
+	}
+}
+
+// Status returns HTTPResponse.Status
+func (r GetTokensResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetTokensResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PostTokensResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON201      *struct {
+		// ExpiresAt Дата истечения токена
+		ExpiresAt *time.Time `json:"expires_at"`
+
+		// Id Идентификатор токена
+		Id *string `json:"id,omitempty"`
+
+		// Role Роль владельца токена
+		Role *string `json:"role,omitempty"`
+
+		// Token Значение токена, показывается только один раз
+		Token *string `json:"token,omitempty"`
+	}
+	JSON400 *struct {
+		Error *string `json:"error,omitempty"`
+	}
+	JSON500 *struct {
+		Error *string `json:"error,omitempty"`
+	}
+}
+
+// Status returns HTTPResponse.Status
+func (r PostTokensResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PostTokensResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type DeleteTokensIdResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON500      *struct {
+		Error *string `json:"error,omitempty"`
+	}
+}
+
+// Status returns HTTPResponse.Status
+func (r DeleteTokensIdResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r DeleteTokensIdResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetUserBannerResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *map[string]interface{}
+	JSON400      *struct {
+		Error *string `json:"error,omitempty"`
+	}
+	JSON429 *struct {
+		Error *string `json:"error,omitempty"`
+	}
+	JSON500 *struct {
+		Error *string `json:"error,omitempty"`
+	}
+}
+
+// Status returns HTTPResponse.Status
+func (r GetUserBannerResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetUserBannerResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PostUserBannerBatchResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *map[string]map[string]interface{}
+	JSON400      *struct {
+		Error *string `json:"error,omitempty"`
+	}
+	JSON429 *struct {
+		Error *string `json:"error,omitempty"`
+	}
+	JSON500 *struct {
+		Error *string `json:"error,omitempty"`
+	}
+}
+
+// Status returns HTTPResponse.Status
+func (r PostUserBannerBatchResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PostUserBannerBatchResponse) StatusCode() int {
 	if r.HTTPResponse != nil {
 		return r.HTTPResponse.StatusCode
 	}
-	return 0
+	return 0
+}
+
+// GetAuditWithResponse request returning *GetAuditResponse
+func (c *ClientWithResponses) GetAuditWithResponse(ctx context.Context, params *GetAuditParams, reqEditors ...RequestEditorFn) (*GetAuditResponse, error) {
+	rsp, err := c.GetAudit(ctx, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetAuditResponse(rsp)
+}
+
+// DeleteBannerWithResponse request returning *DeleteBannerResponse
+func (c *ClientWithResponses) DeleteBannerWithResponse(ctx context.Context, params *DeleteBannerParams, reqEditors ...RequestEditorFn) (*DeleteBannerResponse, error) {
+	rsp, err := c.DeleteBanner(ctx, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseDeleteBannerResponse(rsp)
+}
+
+// GetBannerWithResponse request returning *GetBannerResponse
+func (c *ClientWithResponses) GetBannerWithResponse(ctx context.Context, params *GetBannerParams, reqEditors ...RequestEditorFn) (*GetBannerResponse, error) {
+	rsp, err := c.GetBanner(ctx, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetBannerResponse(rsp)
+}
+
+// PostBannerWithBodyWithResponse request with arbitrary body returning *PostBannerResponse
+func (c *ClientWithResponses) PostBannerWithBodyWithResponse(ctx context.Context, params *PostBannerParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostBannerResponse, error) {
+	rsp, err := c.PostBannerWithBody(ctx, params, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostBannerResponse(rsp)
+}
+
+func (c *ClientWithResponses) PostBannerWithResponse(ctx context.Context, params *PostBannerParams, body PostBannerJSONRequestBody, reqEditors ...RequestEditorFn) (*PostBannerResponse, error) {
+	rsp, err := c.PostBanner(ctx, params, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostBannerResponse(rsp)
+}
+
+// GetBannerExportWithResponse request returning *GetBannerExportResponse
+func (c *ClientWithResponses) GetBannerExportWithResponse(ctx context.Context, params *GetBannerExportParams, reqEditors ...RequestEditorFn) (*GetBannerExportResponse, error) {
+	rsp, err := c.GetBannerExport(ctx, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetBannerExportResponse(rsp)
+}
+
+// PostBannerImportWithBodyWithResponse request with arbitrary body returning *PostBannerImportResponse
+func (c *ClientWithResponses) PostBannerImportWithBodyWithResponse(ctx context.Context, params *PostBannerImportParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostBannerImportResponse, error) {
+	rsp, err := c.PostBannerImportWithBody(ctx, params, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostBannerImportResponse(rsp)
+}
+
+// GetBannerVersionsIdWithResponse request returning *GetBannerVersionsIdResponse
+func (c *ClientWithResponses) GetBannerVersionsIdWithResponse(ctx context.Context, id int, params *GetBannerVersionsIdParams, reqEditors ...RequestEditorFn) (*GetBannerVersionsIdResponse, error) {
+	rsp, err := c.GetBannerVersionsId(ctx, id, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetBannerVersionsIdResponse(rsp)
+}
+
+// PutBannerVersionsIdActivateWithResponse request returning *PutBannerVersionsIdActivateResponse
+func (c *ClientWithResponses) PutBannerVersionsIdActivateWithResponse(ctx context.Context, id int, params *PutBannerVersionsIdActivateParams, reqEditors ...RequestEditorFn) (*PutBannerVersionsIdActivateResponse, error) {
+	rsp, err := c.PutBannerVersionsIdActivate(ctx, id, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePutBannerVersionsIdActivateResponse(rsp)
+}
+
+// DeleteBannerIdWithResponse request returning *DeleteBannerIdResponse
+func (c *ClientWithResponses) DeleteBannerIdWithResponse(ctx context.Context, id int, params *DeleteBannerIdParams, reqEditors ...RequestEditorFn) (*DeleteBannerIdResponse, error) {
+	rsp, err := c.DeleteBannerId(ctx, id, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseDeleteBannerIdResponse(rsp)
+}
+
+// PatchBannerIdWithBodyWithResponse request with arbitrary body returning *PatchBannerIdResponse
+func (c *ClientWithResponses) PatchBannerIdWithBodyWithResponse(ctx context.Context, id int, params *PatchBannerIdParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PatchBannerIdResponse, error) {
+	rsp, err := c.PatchBannerIdWithBody(ctx, id, params, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePatchBannerIdResponse(rsp)
+}
+
+func (c *ClientWithResponses) PatchBannerIdWithResponse(ctx context.Context, id int, params *PatchBannerIdParams, body PatchBannerIdJSONRequestBody, reqEditors ...RequestEditorFn) (*PatchBannerIdResponse, error) {
+	rsp, err := c.PatchBannerId(ctx, id, params, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePatchBannerIdResponse(rsp)
+}
+
+// PostBannerIdRestoreWithResponse request returning *PostBannerIdRestoreResponse
+func (c *ClientWithResponses) PostBannerIdRestoreWithResponse(ctx context.Context, id int, params *PostBannerIdRestoreParams, reqEditors ...RequestEditorFn) (*PostBannerIdRestoreResponse, error) {
+	rsp, err := c.PostBannerIdRestore(ctx, id, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostBannerIdRestoreResponse(rsp)
+}
+
+// DeleteFeatureIdSchemaWithResponse request returning *DeleteFeatureIdSchemaResponse
+func (c *ClientWithResponses) DeleteFeatureIdSchemaWithResponse(ctx context.Context, id int, params *DeleteFeatureIdSchemaParams, reqEditors ...RequestEditorFn) (*DeleteFeatureIdSchemaResponse, error) {
+	rsp, err := c.DeleteFeatureIdSchema(ctx, id, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseDeleteFeatureIdSchemaResponse(rsp)
+}
+
+// GetFeatureIdSchemaWithResponse request returning *GetFeatureIdSchemaResponse
+func (c *ClientWithResponses) GetFeatureIdSchemaWithResponse(ctx context.Context, id int, params *GetFeatureIdSchemaParams, reqEditors ...RequestEditorFn) (*GetFeatureIdSchemaResponse, error) {
+	rsp, err := c.GetFeatureIdSchema(ctx, id, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetFeatureIdSchemaResponse(rsp)
+}
+
+// PutFeatureIdSchemaWithBodyWithResponse request with arbitrary body returning *PutFeatureIdSchemaResponse
+func (c *ClientWithResponses) PutFeatureIdSchemaWithBodyWithResponse(ctx context.Context, id int, params *PutFeatureIdSchemaParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PutFeatureIdSchemaResponse, error) {
+	rsp, err := c.PutFeatureIdSchemaWithBody(ctx, id, params, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePutFeatureIdSchemaResponse(rsp)
+}
+
+func (c *ClientWithResponses) PutFeatureIdSchemaWithResponse(ctx context.Context, id int, params *PutFeatureIdSchemaParams, body PutFeatureIdSchemaJSONRequestBody, reqEditors ...RequestEditorFn) (*PutFeatureIdSchemaResponse, error) {
+	rsp, err := c.PutFeatureIdSchema(ctx, id, params, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePutFeatureIdSchemaResponse(rsp)
+}
+
+// GetJobsIdWithResponse request returning *GetJobsIdResponse
+func (c *ClientWithResponses) GetJobsIdWithResponse(ctx context.Context, id int, params *GetJobsIdParams, reqEditors ...RequestEditorFn) (*GetJobsIdResponse, error) {
+	rsp, err := c.GetJobsId(ctx, id, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetJobsIdResponse(rsp)
+}
+
+// GetTokensWithResponse request returning *GetTokensResponse
+func (c *ClientWithResponses) GetTokensWithResponse(ctx context.Context, params *GetTokensParams, reqEditors ...RequestEditorFn) (*GetTokensResponse, error) {
+	rsp, err := c.GetTokens(ctx, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetTokensResponse(rsp)
+}
+
+// PostTokensWithBodyWithResponse request with arbitrary body returning *PostTokensResponse
+func (c *ClientWithResponses) PostTokensWithBodyWithResponse(ctx context.Context, params *PostTokensParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostTokensResponse, error) {
+	rsp, err := c.PostTokensWithBody(ctx, params, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostTokensResponse(rsp)
+}
+
+func (c *ClientWithResponses) PostTokensWithResponse(ctx context.Context, params *PostTokensParams, body PostTokensJSONRequestBody, reqEditors ...RequestEditorFn) (*PostTokensResponse, error) {
+	rsp, err := c.PostTokens(ctx, params, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostTokensResponse(rsp)
+}
+
+// DeleteTokensIdWithResponse request returning *DeleteTokensIdResponse
+func (c *ClientWithResponses) DeleteTokensIdWithResponse(ctx context.Context, id string, params *DeleteTokensIdParams, reqEditors ...RequestEditorFn) (*DeleteTokensIdResponse, error) {
+	rsp, err := c.DeleteTokensId(ctx, id, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseDeleteTokensIdResponse(rsp)
+}
+
+// GetUserBannerWithResponse request returning *GetUserBannerResponse
+func (c *ClientWithResponses) GetUserBannerWithResponse(ctx context.Context, params *GetUserBannerParams, reqEditors ...RequestEditorFn) (*GetUserBannerResponse, error) {
+	rsp, err := c.GetUserBanner(ctx, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetUserBannerResponse(rsp)
+}
+
+// PostUserBannerBatchWithBodyWithResponse request with arbitrary body returning *PostUserBannerBatchResponse
+func (c *ClientWithResponses) PostUserBannerBatchWithBodyWithResponse(ctx context.Context, params *PostUserBannerBatchParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostUserBannerBatchResponse, error) {
+	rsp, err := c.PostUserBannerBatchWithBody(ctx, params, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostUserBannerBatchResponse(rsp)
+}
+
+func (c *ClientWithResponses) PostUserBannerBatchWithResponse(ctx context.Context, params *PostUserBannerBatchParams, body PostUserBannerBatchJSONRequestBody, reqEditors ...RequestEditorFn) (*PostUserBannerBatchResponse, error) {
+	rsp, err := c.PostUserBannerBatch(ctx, params, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostUserBannerBatchResponse(rsp)
+}
+
+// ParseGetAuditResponse parses an HTTP response from a GetAuditWithResponse call
+func ParseGetAuditResponse(rsp *http.Response) (*GetAuditResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetAuditResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest []struct {
+			// Action Действие (banner.create, banner.update, banner.delete, banner.restore, banner.rollback, banner.purge)
+			Action *string `json:"action,omitempty"`
+
+			// ActorRole Роль токена
+			ActorRole *string `json:"actor_role,omitempty"`
+
+			// ActorTokenId Идентификатор токена, выполнившего изменение
+			ActorTokenId *string `json:"actor_token_id,omitempty"`
+
+			// BannerId Идентификатор баннера
+			BannerId *int `json:"banner_id,omitempty"`
+
+			// CreatedAt Время изменения
+			CreatedAt *time.Time `json:"created_at,omitempty"`
+
+			// Diff Изменённые поля баннера со значениями до (before) и после (after) изменения
+			Diff *map[string]interface{} `json:"diff,omitempty"`
+
+			// Id Идентификатор события
+			Id *int `json:"id,omitempty"`
+
+			// RequestId Идентификатор запроса
+			RequestId *string `json:"request_id,omitempty"`
+		}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest struct {
+			Error *string `json:"error,omitempty"`
+		}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest struct {
+			Error *string `json:"error,omitempty"`
+		}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseDeleteBannerResponse parses an HTTP response from a DeleteBannerWithResponse call
+func ParseDeleteBannerResponse(rsp *http.Response) (*DeleteBannerResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &DeleteBannerResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 202:
+		var dest struct {
+			// JobId Идентификатор задачи удаления
+			JobId *int `json:"job_id,omitempty"`
+		}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON202 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest struct {
+			Error *string `json:"error,omitempty"`
+		}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest struct {
+			Error *string `json:"error,omitempty"`
+		}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetBannerResponse parses an HTTP response from a GetBannerWithResponse call
+func ParseGetBannerResponse(rsp *http.Response) (*GetBannerResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetBannerResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest []struct {
+			// ActiveFrom Начало периода показа баннера
+			ActiveFrom *time.Time `json:"active_from"`
+
+			// ActiveUntil Окончание периода показа баннера
+			ActiveUntil *time.Time `json:"active_until"`
+
+			// BannerId Идентификатор баннера
+			BannerId *int `json:"banner_id,omitempty"`
+
+			// Content Содержимое баннера
+			Content *map[string]interface{} `json:"content,omitempty"`
+
+			// CreatedAt Дата создания баннера
+			CreatedAt *time.Time `json:"created_at,omitempty"`
+
+			// DeletedAt Дата удаления баннера, только для баннеров из корзины
+			DeletedAt *time.Time `json:"deleted_at,omitempty"`
+
+			// FeatureId Идентификатор фичи
+			FeatureId *int `json:"feature_id,omitempty"`
+
+			// IsActive Флаг активности баннера
+			IsActive *bool `json:"is_active,omitempty"`
+
+			// TagIds Идентификаторы тэгов
+			TagIds *[]int `json:"tag_ids,omitempty"`
+
+			// UpdatedAt Дата обновления баннера
+			UpdatedAt *time.Time `json:"updated_at,omitempty"`
+		}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest struct {
+			Error *string `json:"error,omitempty"`
+		}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest struct {
+			Error *string `json:"error,omitempty"`
+		}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParsePostBannerResponse parses an HTTP response from a PostBannerWithResponse call
+func ParsePostBannerResponse(rsp *http.Response) (*PostBannerResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &PostBannerResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 201:
+		var dest struct {
+			// BannerId Идентификатор созданного баннера
+			BannerId *int `json:"banner_id,omitempty"`
+		}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON201 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest struct {
+			Error *string `json:"error,omitempty"`
+
+			// Violations Нарушения JSON Schema фичи для содержимого баннера
+			Violations *[]struct {
+				// Message Описание нарушения
+				Message *string `json:"message,omitempty"`
+
+				// Path JSON Pointer нарушившего схему значения
+				Path *string `json:"path,omitempty"`
+			} `json:"violations,omitempty"`
+		}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest struct {
+			Error *string `json:"error,omitempty"`
+		}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetBannerExportResponse parses an HTTP response from a GetBannerExportWithResponse call
+func ParseGetBannerExportResponse(rsp *http.Response) (*GetBannerExportResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetBannerExportResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest struct {
+			Error *string `json:"error,omitempty"`
+		}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest struct {
+			Error *string `json:"error,omitempty"`
+		}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParsePostBannerImportResponse parses an HTTP response from a PostBannerImportWithResponse call
+func ParsePostBannerImportResponse(rsp *http.Response) (*PostBannerImportResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &PostBannerImportResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest struct {
+			// Created Число созданных баннеров
+			Created *int `json:"created,omitempty"`
+
+			// Error Причина отмены импорта
+			Error *string `json:"error,omitempty"`
+			Lines *[]struct {
+				// BannerId Идентификатор созданного, обновлённого или конфликтующего баннера
+				BannerId *int `json:"banner_id,omitempty"`
+
+				// Error Описание ошибки
+				Error *string `json:"error,omitempty"`
+
+				// Line Номер строки во входных данных
+				Line *int `json:"line,omitempty"`
+
+				// Status Результат (created, updated, skipped, conflict или invalid)
+				Status *string `json:"status,omitempty"`
+			} `json:"lines,omitempty"`
+
+			// Mode Режим импорта
+			Mode *string `json:"mode,omitempty"`
+
+			// Skipped Число пропущенных строк
+			Skipped *int `json:"skipped,omitempty"`
+
+			// Updated Число обновлённых баннеров
+			Updated *int `json:"updated,omitempty"`
+		}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest struct {
+			// Created Число созданных баннеров
+			Created *int `json:"created,omitempty"`
+
+			// Error Причина отмены импорта
+			Error *string `json:"error,omitempty"`
+			Lines *[]struct {
+				// BannerId Идентификатор созданного, обновлённого или конфликтующего баннера
+				BannerId *int `json:"banner_id,omitempty"`
+
+				// Error Описание ошибки
+				Error *string `json:"error,omitempty"`
+
+				// Line Номер строки во входных данных
+				Line *int `json:"line,omitempty"`
+
+				// Status Результат (created, updated, skipped, conflict или invalid)
+				Status *string `json:"status,omitempty"`
+			} `json:"lines,omitempty"`
+
+			// Mode Режим импорта
+			Mode *string `json:"mode,omitempty"`
+
+			// Skipped Число пропущенных строк
+			Skipped *int `json:"skipped,omitempty"`
+
+			// Updated Число обновлённых баннеров
+			Updated *int `json:"updated,omitempty"`
+		}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 409:
+		var dest struct {
+			// Created Число созданных баннеров
+			Created *int `json:"created,omitempty"`
+
+			// Error Причина отмены импорта
+			Error *string `json:"error,omitempty"`
+			Lines *[]struct {
+				// BannerId Идентификатор созданного, обновлённого или конфликтующего баннера
+				BannerId *int `json:"banner_id,omitempty"`
+
+				// Error Описание ошибки
+				Error *string `json:"error,omitempty"`
+
+				// Line Номер строки во входных данных
+				Line *int `json:"line,omitempty"`
+
+				// Status Результат (created, updated, skipped, conflict или invalid)
+				Status *string `json:"status,omitempty"`
+			} `json:"lines,omitempty"`
+
+			// Mode Режим импорта
+			Mode *string `json:"mode,omitempty"`
+
+			// Skipped Число пропущенных строк
+			Skipped *int `json:"skipped,omitempty"`
+
+			// Updated Число обновлённых баннеров
+			Updated *int `json:"updated,omitempty"`
+		}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON409 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest struct {
+			Error *string `json:"error,omitempty"`
+		}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetBannerVersionsIdResponse parses an HTTP response from a GetBannerVersionsIdWithResponse call
+func ParseGetBannerVersionsIdResponse(rsp *http.Response) (*GetBannerVersionsIdResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetBannerVersionsIdResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest []struct {
+			// ActiveFrom Начало периода показа баннера
+			ActiveFrom *time.Time `json:"active_from"`
+
+			// ActiveUntil Окончание периода показа баннера
+			ActiveUntil *time.Time `json:"active_until"`
+
+			// Content Содержимое баннера
+			Content *map[string]interface{} `json:"content,omitempty"`
+
+			// CreatedAt Дата создания версии
+			CreatedAt *time.Time `json:"created_at,omitempty"`
+
+			// FeatureId Идентификатор фичи
+			FeatureId *int `json:"feature_id,omitempty"`
+
+			// IsActive Флаг активности баннера
+			IsActive *bool `json:"is_active,omitempty"`
+
+			// TagIds Идентификаторы тэгов
+			TagIds *[]int `json:"tag_ids,omitempty"`
+
+			// Version Номер версии
+			Version *int `json:"version,omitempty"`
+		}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest struct {
+			Error *string `json:"error,omitempty"`
+		}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParsePutBannerVersionsIdActivateResponse parses an HTTP response from a PutBannerVersionsIdActivateWithResponse call
+func ParsePutBannerVersionsIdActivateResponse(rsp *http.Response) (*PutBannerVersionsIdActivateResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &PutBannerVersionsIdActivateResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest struct {
+			Error *string `json:"error,omitempty"`
+		}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest struct {
+			Error *string `json:"error,omitempty"`
+		}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseDeleteBannerIdResponse parses an HTTP response from a DeleteBannerIdWithResponse call
+func ParseDeleteBannerIdResponse(rsp *http.Response) (*DeleteBannerIdResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &DeleteBannerIdResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest struct {
+			Error *string `json:"error,omitempty"`
+		}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest struct {
+			Error *string `json:"error,omitempty"`
+		}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParsePatchBannerIdResponse parses an HTTP response from a PatchBannerIdWithResponse call
+func ParsePatchBannerIdResponse(rsp *http.Response) (*PatchBannerIdResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &PatchBannerIdResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest struct {
+			Error *string `json:"error,omitempty"`
+
+			// Violations Нарушения JSON Schema фичи для содержимого баннера
+			Violations *[]struct {
+				// Message Описание нарушения
+				Message *string `json:"message,omitempty"`
+
+				// Path JSON Pointer нарушившего схему значения
+				Path *string `json:"path,omitempty"`
+			} `json:"violations,omitempty"`
+		}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest struct {
+			Error *string `json:"error,omitempty"`
+		}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParsePostBannerIdRestoreResponse parses an HTTP response from a PostBannerIdRestoreWithResponse call
+func ParsePostBannerIdRestoreResponse(rsp *http.Response) (*PostBannerIdRestoreResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &PostBannerIdRestoreResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest struct {
+			Error *string `json:"error,omitempty"`
+		}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseDeleteFeatureIdSchemaResponse parses an HTTP response from a DeleteFeatureIdSchemaWithResponse call
+func ParseDeleteFeatureIdSchemaResponse(rsp *http.Response) (*DeleteFeatureIdSchemaResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &DeleteFeatureIdSchemaResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest struct {
+			Error *string `json:"error,omitempty"`
+		}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetFeatureIdSchemaResponse parses an HTTP response from a GetFeatureIdSchemaWithResponse call
+func ParseGetFeatureIdSchemaResponse(rsp *http.Response) (*GetFeatureIdSchemaResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetFeatureIdSchemaResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest map[string]interface{}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest struct {
+			Error *string `json:"error,omitempty"`
+		}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParsePutFeatureIdSchemaResponse parses an HTTP response from a PutFeatureIdSchemaWithResponse call
+func ParsePutFeatureIdSchemaResponse(rsp *http.Response) (*PutFeatureIdSchemaResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &PutFeatureIdSchemaResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest struct {
+			Error *string `json:"error,omitempty"`
+		}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest struct {
+			Error *string `json:"error,omitempty"`
+		}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetJobsIdResponse parses an HTTP response from a GetJobsIdWithResponse call
+func ParseGetJobsIdResponse(rsp *http.Response) (*GetJobsIdResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetJobsIdResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest struct {
+			// CreatedAt Дата создания задачи
+			CreatedAt *time.Time `json:"created_at,omitempty"`
+
+			// Deleted Количество удалённых баннеров
+			Deleted *int `json:"deleted,omitempty"`
+
+			// Error Текст ошибки для упавшей задачи
+			Error *string `json:"error,omitempty"`
+
+			// FeatureId Идентификатор фичи
+			FeatureId *int `json:"feature_id"`
+
+			// JobId Идентификатор задачи
+			JobId *int `json:"job_id,omitempty"`
+
+			// Status Статус задачи (pending, running, done, failed)
+			Status *string `json:"status,omitempty"`
+
+			// TagId Идентификатор тега
+			TagId *int `json:"tag_id"`
+
+			// UpdatedAt Дата обновления задачи
+			UpdatedAt *time.Time `json:"updated_at,omitempty"`
+		}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest struct {
+			Error *string `json:"error,omitempty"`
+		}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetTokensResponse parses an HTTP response from a GetTokensWithResponse call
+func ParseGetTokensResponse(rsp *http.Response) (*GetTokensResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetTokensResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest []struct {
+			// CreatedAt Дата выпуска токена
+			CreatedAt *time.Time `json:"created_at,omitempty"`
+
+			// ExpiresAt Дата истечения токена
+			ExpiresAt *time.Time `json:"expires_at"`
+
+			// Id Идентификатор токена
+			Id *string `json:"id,omitempty"`
+
+			// Revoked Флаг отзыва токена
+			Revoked *bool `json:"revoked,omitempty"`
+
+			// Role Роль владельца токена
+			Role *string `json:"role,omitempty"`
+		}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest struct {
+			Error *string `json:"error,omitempty"`
+		}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParsePostTokensResponse parses an HTTP response from a PostTokensWithResponse call
+func ParsePostTokensResponse(rsp *http.Response) (*PostTokensResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &PostTokensResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 201:
+		var dest struct {
+			// ExpiresAt Дата истечения токена
+			ExpiresAt *time.Time `json:"expires_at"`
+
+			// Id Идентификатор токена
+			Id *string `json:"id,omitempty"`
+
+			// Role Роль владельца токена
+			Role *string `json:"role,omitempty"`
+
+			// Token Значение токена, показывается только один раз
+			Token *string `json:"token,omitempty"`
+		}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON201 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest struct {
+			Error *string `json:"error,omitempty"`
+		}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest struct {
+			Error *string `json:"error,omitempty"`
+		}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseDeleteTokensIdResponse parses an HTTP response from a DeleteTokensIdWithResponse call
+func ParseDeleteTokensIdResponse(rsp *http.Response) (*DeleteTokensIdResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &DeleteTokensIdResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest struct {
+			Error *string `json:"error,omitempty"`
+		}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetUserBannerResponse parses an HTTP response from a GetUserBannerWithResponse call
+func ParseGetUserBannerResponse(rsp *http.Response) (*GetUserBannerResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetUserBannerResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest map[string]interface{}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest struct {
+			Error *string `json:"error,omitempty"`
+		}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 429:
+		var dest struct {
+			Error *string `json:"error,omitempty"`
+		}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON429 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest struct {
+			Error *string `json:"error,omitempty"`
+		}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParsePostUserBannerBatchResponse parses an HTTP response from a PostUserBannerBatchWithResponse call
+func ParsePostUserBannerBatchResponse(rsp *http.Response) (*PostUserBannerBatchResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &PostUserBannerBatchResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest map[string]map[string]interface{}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest struct {
+			Error *string `json:"error,omitempty"`
+		}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 429:
+		var dest struct {
+			Error *string `json:"error,omitempty"`
+		}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON429 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest struct {
+			Error *string `json:"error,omitempty"`
+		}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	}
+
+	return response, nil
 }
 
-type DeleteBannerIdResponse struct {
-	Body         []byte
-	HTTPResponse *http.Response
-	JSON400      *struct {
-		Error *string `json:"error,omitempty"`
+// ServerInterface represents all server handlers.
+type ServerInterface interface {
+	// Журнал изменений баннеров
+	// (GET /audit)
+	GetAudit(ctx echo.Context, params GetAuditParams) error
+	// Отложенное удаление баннеров по фиче и/или тегу
+	// (DELETE /banner)
+	DeleteBanner(ctx echo.Context, params DeleteBannerParams) error
+	// Получение всех баннеров c фильтрацией по фиче и/или тегу
+	// (GET /banner)
+	GetBanner(ctx echo.Context, params GetBannerParams) error
+	// Создание нового баннера
+	// (POST /banner)
+	PostBanner(ctx echo.Context, params PostBannerParams) error
+	// Выгрузка всех баннеров
+	// (GET /banner/export)
+	GetBannerExport(ctx echo.Context, params GetBannerExportParams) error
+	// Загрузка баннеров из выгрузки
+	// (POST /banner/import)
+	PostBannerImport(ctx echo.Context, params PostBannerImportParams) error
+	// Получение истории версий баннера
+	// (GET /banner/versions/{id})
+	GetBannerVersionsId(ctx echo.Context, id int, params GetBannerVersionsIdParams) error
+	// Восстановление баннера из версии
+	// (PUT /banner/versions/{id}/activate)
+	PutBannerVersionsIdActivate(ctx echo.Context, id int, params PutBannerVersionsIdActivateParams) error
+	// Удаление баннера по идентификатору
+	// (DELETE /banner/{id})
+	DeleteBannerId(ctx echo.Context, id int, params DeleteBannerIdParams) error
+	// Обновление содержимого баннера
+	// (PATCH /banner/{id})
+	PatchBannerId(ctx echo.Context, id int, params PatchBannerIdParams) error
+	// Восстановление удалённого баннера из корзины
+	// (POST /banner/{id}/restore)
+	PostBannerIdRestore(ctx echo.Context, id int, params PostBannerIdRestoreParams) error
+	// Удаление JSON Schema фичи
+	// (DELETE /feature/{id}/schema)
+	DeleteFeatureIdSchema(ctx echo.Context, id int, params DeleteFeatureIdSchemaParams) error
+	// Получение JSON Schema содержимого баннеров фичи
+	// (GET /feature/{id}/schema)
+	GetFeatureIdSchema(ctx echo.Context, id int, params GetFeatureIdSchemaParams) error
+	// Установка JSON Schema содержимого баннеров фичи
+	// (PUT /feature/{id}/schema)
+	PutFeatureIdSchema(ctx echo.Context, id int, params PutFeatureIdSchemaParams) error
+	// Получение статуса фоновой задачи
+	// (GET /jobs/{id})
+	GetJobsId(ctx echo.Context, id int, params GetJobsIdParams) error
+	// Получение списка выпущенных токенов
+	// (GET /tokens)
+	GetTokens(ctx echo.Context, params GetTokensParams) error
+	// Выпуск нового токена
+	// (POST /tokens)
+	PostTokens(ctx echo.Context, params PostTokensParams) error
+	// Отзыв токена
+	// (DELETE /tokens/{id})
+	DeleteTokensId(ctx echo.Context, id string, params DeleteTokensIdParams) error
+	// Получение баннера для пользователя
+	// (GET /user_banner)
+	GetUserBanner(ctx echo.Context, params GetUserBannerParams) error
+	// Получение баннеров пользователя для нескольких фич
+	// (POST /user_banner/batch)
+	PostUserBannerBatch(ctx echo.Context, params PostUserBannerBatchParams) error
+}
+
+// ServerInterfaceWrapper converts echo contexts to parameters.
+type ServerInterfaceWrapper struct {
+	Handler ServerInterface
+}
+
+// GetAudit converts echo context to params.
+func (w *ServerInterfaceWrapper) GetAudit(ctx echo.Context) error {
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetAuditParams
+	// ------------- Optional query parameter "banner_id" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "banner_id", ctx.QueryParams(), &params.BannerId)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter banner_id: %s", err))
 	}
-	JSON500 *struct {
-		Error *string `json:"error,omitempty"`
+
+	// ------------- Optional query parameter "actor" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "actor", ctx.QueryParams(), &params.Actor)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter actor: %s", err))
 	}
-}
 
-// Status returns HTTPResponse.Status
-func (r DeleteBannerIdResponse) Status() string {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.Status
+	// ------------- Optional query parameter "from" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "from", ctx.QueryParams(), &params.From)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter from: %s", err))
 	}
-	return http.StatusText(0)
-}
 
-// StatusCode returns HTTPResponse.StatusCode
-func (r DeleteBannerIdResponse) StatusCode() int {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.StatusCode
+	// ------------- Optional query parameter "to" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "to", ctx.QueryParams(), &params.To)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter to: %s", err))
 	}
-	return 0
-}
 
-type PatchBannerIdResponse struct {
-	Body         []byte
-	HTTPResponse *http.Response
-	JSON400      *struct {
-		Error *string `json:"error,omitempty"`
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", ctx.QueryParams(), &params.Limit)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter limit: %s", err))
 	}
-	JSON500 *struct {
-		Error *string `json:"error,omitempty"`
+
+	// ------------- Optional query parameter "offset" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "offset", ctx.QueryParams(), &params.Offset)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter offset: %s", err))
 	}
-}
 
-// Status returns HTTPResponse.Status
-func (r PatchBannerIdResponse) Status() string {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.Status
+	headers := ctx.Request().Header
+	// ------------- Optional header parameter "token" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("token")]; found {
+		var Token string
+		n := len(valueList)
+		if n != 1 {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Expected one value for token, got %d", n))
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "token", valueList[0], &Token, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter token: %s", err))
+		}
+
+		params.Token = &Token
 	}
-	return http.StatusText(0)
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.GetAudit(ctx, params)
+	return err
 }
 
-// StatusCode returns HTTPResponse.StatusCode
-func (r PatchBannerIdResponse) StatusCode() int {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.StatusCode
+// DeleteBanner converts echo context to params.
+func (w *ServerInterfaceWrapper) DeleteBanner(ctx echo.Context) error {
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params DeleteBannerParams
+	// ------------- Optional query parameter "feature_id" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "feature_id", ctx.QueryParams(), &params.FeatureId)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter feature_id: %s", err))
 	}
-	return 0
+
+	// ------------- Optional query parameter "tag_id" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "tag_id", ctx.QueryParams(), &params.TagId)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter tag_id: %s", err))
+	}
+
+	headers := ctx.Request().Header
+	// ------------- Optional header parameter "token" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("token")]; found {
+		var Token string
+		n := len(valueList)
+		if n != 1 {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Expected one value for token, got %d", n))
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "token", valueList[0], &Token, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter token: %s", err))
+		}
+
+		params.Token = &Token
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.DeleteBanner(ctx, params)
+	return err
 }
 
-type GetUserBannerResponse struct {
-	Body         []byte
-	HTTPResponse *http.Response
-	JSON200      *map[string]interface{}
-	JSON400      *struct {
-		Error *string `json:"error,omitempty"`
+// GetBanner converts echo context to params.
+func (w *ServerInterfaceWrapper) GetBanner(ctx echo.Context) error {
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetBannerParams
+	// ------------- Optional query parameter "feature_id" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "feature_id", ctx.QueryParams(), &params.FeatureId)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter feature_id: %s", err))
 	}
-	JSON500 *struct {
-		Error *string `json:"error,omitempty"`
+
+	// ------------- Optional query parameter "tag_id" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "tag_id", ctx.QueryParams(), &params.TagId)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter tag_id: %s", err))
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", ctx.QueryParams(), &params.Limit)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter limit: %s", err))
+	}
+
+	// ------------- Optional query parameter "offset" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "offset", ctx.QueryParams(), &params.Offset)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter offset: %s", err))
+	}
+
+	// ------------- Optional query parameter "cursor" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "cursor", ctx.QueryParams(), &params.Cursor)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter cursor: %s", err))
+	}
+
+	// ------------- Optional query parameter "deleted" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "deleted", ctx.QueryParams(), &params.Deleted)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter deleted: %s", err))
+	}
+
+	headers := ctx.Request().Header
+	// ------------- Optional header parameter "token" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("token")]; found {
+		var Token string
+		n := len(valueList)
+		if n != 1 {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Expected one value for token, got %d", n))
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "token", valueList[0], &Token, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter token: %s", err))
+		}
+
+		params.Token = &Token
 	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.GetBanner(ctx, params)
+	return err
 }
 
-// Status returns HTTPResponse.Status
-func (r GetUserBannerResponse) Status() string {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.Status
+// PostBanner converts echo context to params.
+func (w *ServerInterfaceWrapper) PostBanner(ctx echo.Context) error {
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params PostBannerParams
+
+	headers := ctx.Request().Header
+	// ------------- Optional header parameter "token" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("token")]; found {
+		var Token string
+		n := len(valueList)
+		if n != 1 {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Expected one value for token, got %d", n))
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "token", valueList[0], &Token, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter token: %s", err))
+		}
+
+		params.Token = &Token
 	}
-	return http.StatusText(0)
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.PostBanner(ctx, params)
+	return err
 }
 
-// StatusCode returns HTTPResponse.StatusCode
-func (r GetUserBannerResponse) StatusCode() int {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.StatusCode
+// GetBannerExport converts echo context to params.
+func (w *ServerInterfaceWrapper) GetBannerExport(ctx echo.Context) error {
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetBannerExportParams
+	// ------------- Optional query parameter "format" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "format", ctx.QueryParams(), &params.Format)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter format: %s", err))
+	}
+
+	headers := ctx.Request().Header
+	// ------------- Optional header parameter "token" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("token")]; found {
+		var Token string
+		n := len(valueList)
+		if n != 1 {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Expected one value for token, got %d", n))
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "token", valueList[0], &Token, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter token: %s", err))
+		}
+
+		params.Token = &Token
 	}
-	return 0
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.GetBannerExport(ctx, params)
+	return err
 }
 
-// GetBannerWithResponse request returning *GetBannerResponse
-func (c *ClientWithResponses) GetBannerWithResponse(ctx context.Context, params *GetBannerParams, reqEditors ...RequestEditorFn) (*GetBannerResponse, error) {
-	rsp, err := c.GetBanner(ctx, params, reqEditors...)
+// PostBannerImport converts echo context to params.
+func (w *ServerInterfaceWrapper) PostBannerImport(ctx echo.Context) error {
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params PostBannerImportParams
+	// ------------- Optional query parameter "format" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "format", ctx.QueryParams(), &params.Format)
 	if err != nil {
-		return nil, err
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter format: %s", err))
 	}
-	return ParseGetBannerResponse(rsp)
-}
 
-// PostBannerWithBodyWithResponse request with arbitrary body returning *PostBannerResponse
-func (c *ClientWithResponses) PostBannerWithBodyWithResponse(ctx context.Context, params *PostBannerParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostBannerResponse, error) {
-	rsp, err := c.PostBannerWithBody(ctx, params, contentType, body, reqEditors...)
+	// ------------- Optional query parameter "mode" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "mode", ctx.QueryParams(), &params.Mode)
 	if err != nil {
-		return nil, err
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter mode: %s", err))
 	}
-	return ParsePostBannerResponse(rsp)
-}
 
-func (c *ClientWithResponses) PostBannerWithResponse(ctx context.Context, params *PostBannerParams, body PostBannerJSONRequestBody, reqEditors ...RequestEditorFn) (*PostBannerResponse, error) {
-	rsp, err := c.PostBanner(ctx, params, body, reqEditors...)
-	if err != nil {
-		return nil, err
+	headers := ctx.Request().Header
+	// ------------- Optional header parameter "token" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("token")]; found {
+		var Token string
+		n := len(valueList)
+		if n != 1 {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Expected one value for token, got %d", n))
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "token", valueList[0], &Token, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter token: %s", err))
+		}
+
+		params.Token = &Token
 	}
-	return ParsePostBannerResponse(rsp)
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.PostBannerImport(ctx, params)
+	return err
 }
 
-// DeleteBannerIdWithResponse request returning *DeleteBannerIdResponse
-func (c *ClientWithResponses) DeleteBannerIdWithResponse(ctx context.Context, id int, params *DeleteBannerIdParams, reqEditors ...RequestEditorFn) (*DeleteBannerIdResponse, error) {
-	rsp, err := c.DeleteBannerId(ctx, id, params, reqEditors...)
+// GetBannerVersionsId converts echo context to params.
+func (w *ServerInterfaceWrapper) GetBannerVersionsId(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "id" -------------
+	var id int
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", ctx.Param("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
 	if err != nil {
-		return nil, err
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter id: %s", err))
 	}
-	return ParseDeleteBannerIdResponse(rsp)
-}
 
-// PatchBannerIdWithBodyWithResponse request with arbitrary body returning *PatchBannerIdResponse
-func (c *ClientWithResponses) PatchBannerIdWithBodyWithResponse(ctx context.Context, id int, params *PatchBannerIdParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PatchBannerIdResponse, error) {
-	rsp, err := c.PatchBannerIdWithBody(ctx, id, params, contentType, body, reqEditors...)
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetBannerVersionsIdParams
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", ctx.QueryParams(), &params.Limit)
 	if err != nil {
-		return nil, err
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter limit: %s", err))
 	}
-	return ParsePatchBannerIdResponse(rsp)
-}
 
-func (c *ClientWithResponses) PatchBannerIdWithResponse(ctx context.Context, id int, params *PatchBannerIdParams, body PatchBannerIdJSONRequestBody, reqEditors ...RequestEditorFn) (*PatchBannerIdResponse, error) {
-	rsp, err := c.PatchBannerId(ctx, id, params, body, reqEditors...)
-	if err != nil {
-		return nil, err
+	headers := ctx.Request().Header
+	// ------------- Optional header parameter "token" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("token")]; found {
+		var Token string
+		n := len(valueList)
+		if n != 1 {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Expected one value for token, got %d", n))
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "token", valueList[0], &Token, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter token: %s", err))
+		}
+
+		params.Token = &Token
 	}
-	return ParsePatchBannerIdResponse(rsp)
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.GetBannerVersionsId(ctx, id, params)
+	return err
 }
 
-// GetUserBannerWithResponse request returning *GetUserBannerResponse
-func (c *ClientWithResponses) GetUserBannerWithResponse(ctx context.Context, params *GetUserBannerParams, reqEditors ...RequestEditorFn) (*GetUserBannerResponse, error) {
-	rsp, err := c.GetUserBanner(ctx, params, reqEditors...)
+// PutBannerVersionsIdActivate converts echo context to params.
+func (w *ServerInterfaceWrapper) PutBannerVersionsIdActivate(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "id" -------------
+	var id int
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", ctx.Param("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
 	if err != nil {
-		return nil, err
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter id: %s", err))
 	}
-	return ParseGetUserBannerResponse(rsp)
-}
 
-// ParseGetBannerResponse parses an HTTP response from a GetBannerWithResponse call
-func ParseGetBannerResponse(rsp *http.Response) (*GetBannerResponse, error) {
-	bodyBytes, err := io.ReadAll(rsp.Body)
-	defer func() { _ = rsp.Body.Close() }()
+	// Parameter object where we will unmarshal all parameters from the context
+	var params PutBannerVersionsIdActivateParams
+	// ------------- Required query parameter "version" -------------
+
+	err = runtime.BindQueryParameter("form", true, true, "version", ctx.QueryParams(), &params.Version)
 	if err != nil {
-		return nil, err
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter version: %s", err))
 	}
 
-	response := &GetBannerResponse{
-		Body:         bodyBytes,
-		HTTPResponse: rsp,
-	}
+	headers := ctx.Request().Header
+	// ------------- Optional header parameter "token" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("token")]; found {
+		var Token string
+		n := len(valueList)
+		if n != 1 {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Expected one value for token, got %d", n))
+		}
 
-	switch {
-	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
-		var dest []struct {
-			// BannerId Идентификатор баннера
-			BannerId *int `json:"banner_id,omitempty"`
+		err = runtime.BindStyledParameterWithOptions("simple", "token", valueList[0], &Token, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter token: %s", err))
+		}
 
-			// Content Содержимое баннера
-			Content *map[string]interface{} `json:"content,omitempty"`
+		params.Token = &Token
+	}
 
-			// CreatedAt Дата создания баннера
-			CreatedAt *time.Time `json:"created_at,omitempty"`
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.PutBannerVersionsIdActivate(ctx, id, params)
+	return err
+}
 
-			// FeatureId Идентификатор фичи
-			FeatureId *int `json:"feature_id,omitempty"`
+// DeleteBannerId converts echo context to params.
+func (w *ServerInterfaceWrapper) DeleteBannerId(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "id" -------------
+	var id int
 
-			// IsActive Флаг активности баннера
-			IsActive *bool `json:"is_active,omitempty"`
+	err = runtime.BindStyledParameterWithOptions("simple", "id", ctx.Param("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter id: %s", err))
+	}
 
-			// TagIds Идентификаторы тэгов
-			TagIds *[]int `json:"tag_ids,omitempty"`
+	// Parameter object where we will unmarshal all parameters from the context
+	var params DeleteBannerIdParams
 
-			// UpdatedAt Дата обновления баннера
-			UpdatedAt *time.Time `json:"updated_at,omitempty"`
-		}
-		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
-			return nil, err
+	headers := ctx.Request().Header
+	// ------------- Optional header parameter "token" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("token")]; found {
+		var Token string
+		n := len(valueList)
+		if n != 1 {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Expected one value for token, got %d", n))
 		}
-		response.JSON200 = &dest
 
-	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
-		var dest struct {
-			Error *string `json:"error,omitempty"`
-		}
-		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
-			return nil, err
+		err = runtime.BindStyledParameterWithOptions("simple", "token", valueList[0], &Token, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter token: %s", err))
 		}
-		response.JSON500 = &dest
 
+		params.Token = &Token
 	}
 
-	return response, nil
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.DeleteBannerId(ctx, id, params)
+	return err
 }
 
-// ParsePostBannerResponse parses an HTTP response from a PostBannerWithResponse call
-func ParsePostBannerResponse(rsp *http.Response) (*PostBannerResponse, error) {
-	bodyBytes, err := io.ReadAll(rsp.Body)
-	defer func() { _ = rsp.Body.Close() }()
-	if err != nil {
-		return nil, err
-	}
+// PatchBannerId converts echo context to params.
+func (w *ServerInterfaceWrapper) PatchBannerId(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "id" -------------
+	var id int
 
-	response := &PostBannerResponse{
-		Body:         bodyBytes,
-		HTTPResponse: rsp,
+	err = runtime.BindStyledParameterWithOptions("simple", "id", ctx.Param("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter id: %s", err))
 	}
 
-	switch {
-	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 201:
-		var dest struct {
-			// BannerId Идентификатор созданного баннера
-			BannerId *int `json:"banner_id,omitempty"`
-		}
-		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
-			return nil, err
-		}
-		response.JSON201 = &dest
+	// Parameter object where we will unmarshal all parameters from the context
+	var params PatchBannerIdParams
 
-	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
-		var dest struct {
-			Error *string `json:"error,omitempty"`
-		}
-		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
-			return nil, err
+	headers := ctx.Request().Header
+	// ------------- Optional header parameter "token" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("token")]; found {
+		var Token string
+		n := len(valueList)
+		if n != 1 {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Expected one value for token, got %d", n))
 		}
-		response.JSON400 = &dest
 
-	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
-		var dest struct {
-			Error *string `json:"error,omitempty"`
-		}
-		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
-			return nil, err
+		err = runtime.BindStyledParameterWithOptions("simple", "token", valueList[0], &Token, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter token: %s", err))
 		}
-		response.JSON500 = &dest
 
+		params.Token = &Token
 	}
 
-	return response, nil
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.PatchBannerId(ctx, id, params)
+	return err
 }
 
-// ParseDeleteBannerIdResponse parses an HTTP response from a DeleteBannerIdWithResponse call
-func ParseDeleteBannerIdResponse(rsp *http.Response) (*DeleteBannerIdResponse, error) {
-	bodyBytes, err := io.ReadAll(rsp.Body)
-	defer func() { _ = rsp.Body.Close() }()
+// PostBannerIdRestore converts echo context to params.
+func (w *ServerInterfaceWrapper) PostBannerIdRestore(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "id" -------------
+	var id int
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", ctx.Param("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
 	if err != nil {
-		return nil, err
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter id: %s", err))
 	}
 
-	response := &DeleteBannerIdResponse{
-		Body:         bodyBytes,
-		HTTPResponse: rsp,
-	}
+	// Parameter object where we will unmarshal all parameters from the context
+	var params PostBannerIdRestoreParams
 
-	switch {
-	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
-		var dest struct {
-			Error *string `json:"error,omitempty"`
-		}
-		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
-			return nil, err
+	headers := ctx.Request().Header
+	// ------------- Optional header parameter "token" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("token")]; found {
+		var Token string
+		n := len(valueList)
+		if n != 1 {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Expected one value for token, got %d", n))
 		}
-		response.JSON400 = &dest
 
-	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
-		var dest struct {
-			Error *string `json:"error,omitempty"`
-		}
-		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
-			return nil, err
+		err = runtime.BindStyledParameterWithOptions("simple", "token", valueList[0], &Token, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter token: %s", err))
 		}
-		response.JSON500 = &dest
 
+		params.Token = &Token
 	}
 
-	return response, nil
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.PostBannerIdRestore(ctx, id, params)
+	return err
 }
 
-// ParsePatchBannerIdResponse parses an HTTP response from a PatchBannerIdWithResponse call
-func ParsePatchBannerIdResponse(rsp *http.Response) (*PatchBannerIdResponse, error) {
-	bodyBytes, err := io.ReadAll(rsp.Body)
-	defer func() { _ = rsp.Body.Close() }()
+// DeleteFeatureIdSchema converts echo context to params.
+func (w *ServerInterfaceWrapper) DeleteFeatureIdSchema(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "id" -------------
+	var id int
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", ctx.Param("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
 	if err != nil {
-		return nil, err
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter id: %s", err))
 	}
 
-	response := &PatchBannerIdResponse{
-		Body:         bodyBytes,
-		HTTPResponse: rsp,
-	}
+	// Parameter object where we will unmarshal all parameters from the context
+	var params DeleteFeatureIdSchemaParams
 
-	switch {
-	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
-		var dest struct {
-			Error *string `json:"error,omitempty"`
-		}
-		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
-			return nil, err
+	headers := ctx.Request().Header
+	// ------------- Optional header parameter "token" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("token")]; found {
+		var Token string
+		n := len(valueList)
+		if n != 1 {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Expected one value for token, got %d", n))
 		}
-		response.JSON400 = &dest
 
-	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
-		var dest struct {
-			Error *string `json:"error,omitempty"`
-		}
-		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
-			return nil, err
+		err = runtime.BindStyledParameterWithOptions("simple", "token", valueList[0], &Token, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter token: %s", err))
 		}
-		response.JSON500 = &dest
 
+		params.Token = &Token
 	}
 
-	return response, nil
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.DeleteFeatureIdSchema(ctx, id, params)
+	return err
 }
 
-// ParseGetUserBannerResponse parses an HTTP response from a GetUserBannerWithResponse call
-func ParseGetUserBannerResponse(rsp *http.Response) (*GetUserBannerResponse, error) {
-	bodyBytes, err := io.ReadAll(rsp.Body)
-	defer func() { _ = rsp.Body.Close() }()
-	if err != nil {
-		return nil, err
-	}
+// GetFeatureIdSchema converts echo context to params.
+func (w *ServerInterfaceWrapper) GetFeatureIdSchema(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "id" -------------
+	var id int
 
-	response := &GetUserBannerResponse{
-		Body:         bodyBytes,
-		HTTPResponse: rsp,
+	err = runtime.BindStyledParameterWithOptions("simple", "id", ctx.Param("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter id: %s", err))
 	}
 
-	switch {
-	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
-		var dest map[string]interface{}
-		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
-			return nil, err
-		}
-		response.JSON200 = &dest
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetFeatureIdSchemaParams
 
-	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
-		var dest struct {
-			Error *string `json:"error,omitempty"`
-		}
-		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
-			return nil, err
+	headers := ctx.Request().Header
+	// ------------- Optional header parameter "token" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("token")]; found {
+		var Token string
+		n := len(valueList)
+		if n != 1 {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Expected one value for token, got %d", n))
 		}
-		response.JSON400 = &dest
 
-	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
-		var dest struct {
-			Error *string `json:"error,omitempty"`
-		}
-		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
-			return nil, err
+		err = runtime.BindStyledParameterWithOptions("simple", "token", valueList[0], &Token, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter token: %s", err))
 		}
-		response.JSON500 = &dest
 
+		params.Token = &Token
 	}
 
-	return response, nil
-}
-
-// ServerInterface represents all server handlers.
-type ServerInterface interface {
-	// Получение всех баннеров c фильтрацией по фиче и/или тегу
-	// (GET /banner)
-	GetBanner(ctx echo.Context, params GetBannerParams) error
-	// Создание нового баннера
-	// (POST /banner)
-	PostBanner(ctx echo.Context, params PostBannerParams) error
-	// Удаление баннера по идентификатору
-	// (DELETE /banner/{id})
-	DeleteBannerId(ctx echo.Context, id int, params DeleteBannerIdParams) error
-	// Обновление содержимого баннера
-	// (PATCH /banner/{id})
-	PatchBannerId(ctx echo.Context, id int, params PatchBannerIdParams) error
-	// Получение баннера для пользователя
-	// (GET /user_banner)
-	GetUserBanner(ctx echo.Context, params GetUserBannerParams) error
-}
-
-// ServerInterfaceWrapper converts echo contexts to parameters.
-type ServerInterfaceWrapper struct {
-	Handler ServerInterface
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.GetFeatureIdSchema(ctx, id, params)
+	return err
 }
 
-// GetBanner converts echo context to params.
-func (w *ServerInterfaceWrapper) GetBanner(ctx echo.Context) error {
+// PutFeatureIdSchema converts echo context to params.
+func (w *ServerInterfaceWrapper) PutFeatureIdSchema(ctx echo.Context) error {
 	var err error
+	// ------------- Path parameter "id" -------------
+	var id int
 
-	// Parameter object where we will unmarshal all parameters from the context
-	var params GetBannerParams
-	// ------------- Optional query parameter "feature_id" -------------
-
-	err = runtime.BindQueryParameter("form", true, false, "feature_id", ctx.QueryParams(), &params.FeatureId)
+	err = runtime.BindStyledParameterWithOptions("simple", "id", ctx.Param("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
 	if err != nil {
-		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter feature_id: %s", err))
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter id: %s", err))
 	}
 
-	// ------------- Optional query parameter "tag_id" -------------
+	// Parameter object where we will unmarshal all parameters from the context
+	var params PutFeatureIdSchemaParams
 
-	err = runtime.BindQueryParameter("form", true, false, "tag_id", ctx.QueryParams(), &params.TagId)
-	if err != nil {
-		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter tag_id: %s", err))
-	}
+	headers := ctx.Request().Header
+	// ------------- Optional header parameter "token" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("token")]; found {
+		var Token string
+		n := len(valueList)
+		if n != 1 {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Expected one value for token, got %d", n))
+		}
 
-	// ------------- Optional query parameter "limit" -------------
+		err = runtime.BindStyledParameterWithOptions("simple", "token", valueList[0], &Token, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter token: %s", err))
+		}
 
-	err = runtime.BindQueryParameter("form", true, false, "limit", ctx.QueryParams(), &params.Limit)
-	if err != nil {
-		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter limit: %s", err))
+		params.Token = &Token
 	}
 
-	// ------------- Optional query parameter "offset" -------------
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.PutFeatureIdSchema(ctx, id, params)
+	return err
+}
 
-	err = runtime.BindQueryParameter("form", true, false, "offset", ctx.QueryParams(), &params.Offset)
+// GetJobsId converts echo context to params.
+func (w *ServerInterfaceWrapper) GetJobsId(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "id" -------------
+	var id int
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", ctx.Param("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
 	if err != nil {
-		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter offset: %s", err))
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter id: %s", err))
 	}
 
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetJobsIdParams
+
 	headers := ctx.Request().Header
 	// ------------- Optional header parameter "token" -------------
 	if valueList, found := headers[http.CanonicalHeaderKey("token")]; found {
@@ -1216,16 +4909,16 @@ func (w *ServerInterfaceWrapper) GetBanner(ctx echo.Context) error {
 	}
 
 	// Invoke the callback with all the unmarshaled arguments
-	err = w.Handler.GetBanner(ctx, params)
+	err = w.Handler.GetJobsId(ctx, id, params)
 	return err
 }
 
-// PostBanner converts echo context to params.
-func (w *ServerInterfaceWrapper) PostBanner(ctx echo.Context) error {
+// GetTokens converts echo context to params.
+func (w *ServerInterfaceWrapper) GetTokens(ctx echo.Context) error {
 	var err error
 
 	// Parameter object where we will unmarshal all parameters from the context
-	var params PostBannerParams
+	var params GetTokensParams
 
 	headers := ctx.Request().Header
 	// ------------- Optional header parameter "token" -------------
@@ -1245,23 +4938,16 @@ func (w *ServerInterfaceWrapper) PostBanner(ctx echo.Context) error {
 	}
 
 	// Invoke the callback with all the unmarshaled arguments
-	err = w.Handler.PostBanner(ctx, params)
+	err = w.Handler.GetTokens(ctx, params)
 	return err
 }
 
-// DeleteBannerId converts echo context to params.
-func (w *ServerInterfaceWrapper) DeleteBannerId(ctx echo.Context) error {
+// PostTokens converts echo context to params.
+func (w *ServerInterfaceWrapper) PostTokens(ctx echo.Context) error {
 	var err error
-	// ------------- Path parameter "id" -------------
-	var id int
-
-	err = runtime.BindStyledParameterWithOptions("simple", "id", ctx.Param("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
-	if err != nil {
-		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter id: %s", err))
-	}
 
 	// Parameter object where we will unmarshal all parameters from the context
-	var params DeleteBannerIdParams
+	var params PostTokensParams
 
 	headers := ctx.Request().Header
 	// ------------- Optional header parameter "token" -------------
@@ -1281,15 +4967,15 @@ func (w *ServerInterfaceWrapper) DeleteBannerId(ctx echo.Context) error {
 	}
 
 	// Invoke the callback with all the unmarshaled arguments
-	err = w.Handler.DeleteBannerId(ctx, id, params)
+	err = w.Handler.PostTokens(ctx, params)
 	return err
 }
 
-// PatchBannerId converts echo context to params.
-func (w *ServerInterfaceWrapper) PatchBannerId(ctx echo.Context) error {
+// DeleteTokensId converts echo context to params.
+func (w *ServerInterfaceWrapper) DeleteTokensId(ctx echo.Context) error {
 	var err error
 	// ------------- Path parameter "id" -------------
-	var id int
+	var id string
 
 	err = runtime.BindStyledParameterWithOptions("simple", "id", ctx.Param("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
 	if err != nil {
@@ -1297,7 +4983,7 @@ func (w *ServerInterfaceWrapper) PatchBannerId(ctx echo.Context) error {
 	}
 
 	// Parameter object where we will unmarshal all parameters from the context
-	var params PatchBannerIdParams
+	var params DeleteTokensIdParams
 
 	headers := ctx.Request().Header
 	// ------------- Optional header parameter "token" -------------
@@ -1317,7 +5003,7 @@ func (w *ServerInterfaceWrapper) PatchBannerId(ctx echo.Context) error {
 	}
 
 	// Invoke the callback with all the unmarshaled arguments
-	err = w.Handler.PatchBannerId(ctx, id, params)
+	err = w.Handler.DeleteTokensId(ctx, id, params)
 	return err
 }
 
@@ -1370,6 +5056,35 @@ func (w *ServerInterfaceWrapper) GetUserBanner(ctx echo.Context) error {
 	return err
 }
 
+// PostUserBannerBatch converts echo context to params.
+func (w *ServerInterfaceWrapper) PostUserBannerBatch(ctx echo.Context) error {
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params PostUserBannerBatchParams
+
+	headers := ctx.Request().Header
+	// ------------- Optional header parameter "token" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("token")]; found {
+		var Token string
+		n := len(valueList)
+		if n != 1 {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Expected one value for token, got %d", n))
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "token", valueList[0], &Token, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter token: %s", err))
+		}
+
+		params.Token = &Token
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.PostUserBannerBatch(ctx, params)
+	return err
+}
+
 // This is a simple interface which specifies echo.Route addition functions which
 // are present on both echo.Echo and echo.Group, since we want to allow using
 // either of them for path registration
@@ -1398,10 +5113,25 @@ func RegisterHandlersWithBaseURL(router EchoRouter, si ServerInterface, baseURL
 		Handler: si,
 	}
 
+	router.GET(baseURL+"/audit", wrapper.GetAudit)
+	router.DELETE(baseURL+"/banner", wrapper.DeleteBanner)
 	router.GET(baseURL+"/banner", wrapper.GetBanner)
 	router.POST(baseURL+"/banner", wrapper.PostBanner)
+	router.GET(baseURL+"/banner/export", wrapper.GetBannerExport)
+	router.POST(baseURL+"/banner/import", wrapper.PostBannerImport)
+	router.GET(baseURL+"/banner/versions/:id", wrapper.GetBannerVersionsId)
+	router.PUT(baseURL+"/banner/versions/:id/activate", wrapper.PutBannerVersionsIdActivate)
 	router.DELETE(baseURL+"/banner/:id", wrapper.DeleteBannerId)
 	router.PATCH(baseURL+"/banner/:id", wrapper.PatchBannerId)
+	router.POST(baseURL+"/banner/:id/restore", wrapper.PostBannerIdRestore)
+	router.DELETE(baseURL+"/feature/:id/schema", wrapper.DeleteFeatureIdSchema)
+	router.GET(baseURL+"/feature/:id/schema", wrapper.GetFeatureIdSchema)
+	router.PUT(baseURL+"/feature/:id/schema", wrapper.PutFeatureIdSchema)
+	router.GET(baseURL+"/jobs/:id", wrapper.GetJobsId)
+	router.GET(baseURL+"/tokens", wrapper.GetTokens)
+	router.POST(baseURL+"/tokens", wrapper.PostTokens)
+	router.DELETE(baseURL+"/tokens/:id", wrapper.DeleteTokensId)
 	router.GET(baseURL+"/user_banner", wrapper.GetUserBanner)
+	router.POST(baseURL+"/user_banner/batch", wrapper.PostUserBannerBatch)
 
 }
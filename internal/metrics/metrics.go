@@ -0,0 +1,154 @@
+package metrics
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/labstack/echo/v4"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const namespace = "banner_service"
+
+// Cache layers and results recorded by ObserveCache.
+const (
+	CacheLocal = "local"
+	CacheRedis = "redis"
+
+	CacheHit   = "hit"
+	CacheMiss  = "miss"
+	CacheError = "error"
+	// CacheBypass means the layer was not asked, e.g. because the Redis
+	// circuit breaker is open.
+	CacheBypass = "bypass"
+)
+
+// Metrics owns a registry with the service metrics. A nil *Metrics records
+// nothing, so components can be used without it in tests.
+type Metrics struct {
+	registry *prometheus.Registry
+
+	httpRequests *prometheus.CounterVec
+	httpDuration *prometheus.HistogramVec
+	cacheResults *prometheus.CounterVec
+	dbDuration   *prometheus.HistogramVec
+}
+
+func New() *Metrics {
+	m := &Metrics{
+		registry: prometheus.NewRegistry(),
+		httpRequests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "http_requests_total",
+			Help:      "HTTP requests by route, method and status code.",
+		}, []string{"route", "method", "status"}),
+		httpDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "http_request_duration_seconds",
+			Help:      "HTTP request latency by route, method and status code.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"route", "method", "status"}),
+		cacheResults: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "banner_cache_requests_total",
+			Help:      "User banner cache lookups by layer and result.",
+		}, []string{"layer", "result"}),
+		dbDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "db_query_duration_seconds",
+			Help:      "Database statement latency by operation and table.",
+			Buckets:   []float64{.0005, .001, .0025, .005, .01, .025, .05, .1, .25, .5, 1},
+		}, []string{"operation", "table"}),
+	}
+
+	m.registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		m.httpRequests,
+		m.httpDuration,
+		m.cacheResults,
+		m.dbDuration,
+	)
+	return m
+}
+
+// Handler serves the registry in the Prometheus exposition format.
+func (m *Metrics) Handler() http.Handler {
+	return promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{Registry: m.registry})
+}
+
+// Middleware records every request under its route template, e.g.
+// /banner/:id, so that ids do not blow up the label cardinality.
+func (m *Metrics) Middleware() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			start := time.Now()
+			err := next(c)
+
+			status := c.Response().Status
+			if err != nil {
+				var httpErr *echo.HTTPError
+				if errors.As(err, &httpErr) {
+					status = httpErr.Code
+				} else {
+					status = http.StatusInternalServerError
+				}
+			}
+
+			route := c.Path()
+			if route == "" {
+				route = "unmatched"
+			}
+			labels := prometheus.Labels{
+				"route":  route,
+				"method": c.Request().Method,
+				"status": strconv.Itoa(status),
+			}
+			m.httpRequests.With(labels).Inc()
+			m.httpDuration.With(labels).Observe(time.Since(start).Seconds())
+			return err
+		}
+	}
+}
+
+func (m *Metrics) ObserveCache(layer, result string) {
+	if m == nil {
+		return
+	}
+	m.cacheResults.WithLabelValues(layer, result).Inc()
+}
+
+// RegisterDBPool exports the connection pool statistics of the database.
+func (m *Metrics) RegisterDBPool(db *sql.DB, name string) {
+	m.registry.MustRegister(collectors.NewDBStatsCollector(db, name))
+}
+
+// RegisterRedisPool exports the connection pool statistics of the client.
+func (m *Metrics) RegisterRedisPool(client *redis.Client) {
+	gauge := func(name, help string, value func(*redis.PoolStats) uint32) prometheus.Collector {
+		return prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      name,
+			Help:      help,
+		}, func() float64 {
+			return float64(value(client.PoolStats()))
+		})
+	}
+
+	m.registry.MustRegister(
+		gauge("redis_pool_total_connections", "Connections in the Redis pool.",
+			func(s *redis.PoolStats) uint32 { return s.TotalConns }),
+		gauge("redis_pool_idle_connections", "Idle connections in the Redis pool.",
+			func(s *redis.PoolStats) uint32 { return s.IdleConns }),
+		gauge("redis_pool_stale_connections", "Stale connections removed from the Redis pool.",
+			func(s *redis.PoolStats) uint32 { return s.StaleConns }),
+		gauge("redis_pool_timeouts", "Times a Redis connection could not be taken from the pool in time.",
+			func(s *redis.PoolStats) uint32 { return s.Timeouts }),
+	)
+}
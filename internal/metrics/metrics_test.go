@@ -0,0 +1,52 @@
+package metrics
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMiddlewareLabelsByRouteAndStatus(t *testing.T) {
+	m := New()
+	e := echo.New()
+	e.Use(m.Middleware())
+	e.GET("/banner/:id", func(c echo.Context) error {
+		if c.Param("id") == "0" {
+			return echo.NewHTTPError(http.StatusNotFound, "Banner not found")
+		}
+		return c.NoContent(http.StatusOK)
+	})
+
+	for _, path := range []string{"/banner/1", "/banner/2", "/banner/0"} {
+		e.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, path, nil))
+	}
+
+	assert.Equal(t, 2.0, testutil.ToFloat64(m.httpRequests.WithLabelValues("/banner/:id", http.MethodGet, "200")))
+	assert.Equal(t, 1.0, testutil.ToFloat64(m.httpRequests.WithLabelValues("/banner/:id", http.MethodGet, "404")))
+}
+
+func TestHandlerExposesCacheCounters(t *testing.T) {
+	m := New()
+	m.ObserveCache(CacheRedis, CacheHit)
+	m.ObserveCache(CacheRedis, CacheMiss)
+	m.ObserveCache(CacheRedis, CacheMiss)
+
+	rec := httptest.NewRecorder()
+	m.Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	body := rec.Body.String()
+	assert.True(t, strings.Contains(body, `banner_service_banner_cache_requests_total{layer="redis",result="miss"} 2`), body)
+	assert.True(t, strings.Contains(body, `banner_service_banner_cache_requests_total{layer="redis",result="hit"} 1`), body)
+}
+
+func TestNilMetricsIgnoresObservations(t *testing.T) {
+	var m *Metrics
+	assert.NotPanics(t, func() { m.ObserveCache(CacheLocal, CacheHit) })
+}
@@ -0,0 +1,207 @@
+package server
+
+import (
+	"avito/internal/db"
+	"avito/internal/generated"
+	"avito/internal/server/middleware"
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"gorm.io/gorm"
+)
+
+const (
+	defaultAuditLimit = 100
+	maxAuditLimit     = 1000
+)
+
+// auditSnapshot is the state of a banner as recorded in the audit log.
+type auditSnapshot struct {
+	Content     json.RawMessage `json:"content"`
+	IsActive    bool            `json:"is_active"`
+	ActiveFrom  *time.Time      `json:"active_from"`
+	ActiveUntil *time.Time      `json:"active_until"`
+	FeatureID   int             `json:"feature_id"`
+	TagIDs      []int           `json:"tag_ids"`
+}
+
+// auditChange is a single field of an audit diff. A nil side means the
+// banner did not exist before or after the change.
+type auditChange struct {
+	Before json.RawMessage `json:"before"`
+	After  json.RawMessage `json:"after"`
+}
+
+type AuditEventResponse struct {
+	ID           uint            `json:"id"`
+	Action       string          `json:"action"`
+	BannerID     uint            `json:"banner_id"`
+	ActorTokenID string          `json:"actor_token_id"`
+	ActorRole    string          `json:"actor_role"`
+	Diff         json.RawMessage `json:"diff"`
+	RequestID    string          `json:"request_id"`
+	CreatedAt    time.Time       `json:"created_at"`
+}
+
+// newAuditSnapshot sorts the tag ids, so reordering tags is not reported as
+// a change.
+func newAuditSnapshot(banner db.Banner, featureId int, tagIds []int) *auditSnapshot {
+	sorted := append([]int{}, tagIds...)
+	sort.Ints(sorted)
+	return &auditSnapshot{
+		Content:     banner.Content,
+		IsActive:    banner.IsActive,
+		ActiveFrom:  banner.ActiveFrom,
+		ActiveUntil: banner.ActiveUntil,
+		FeatureID:   featureId,
+		TagIDs:      sorted,
+	}
+}
+
+// pairsSnapshot builds a snapshot from the banner's feature/tag rows.
+func pairsSnapshot(banner db.Banner, pairs []db.BannerFeatureTag) *auditSnapshot {
+	var featureId int
+	tagIds := make([]int, 0, len(pairs))
+	for _, pair := range pairs {
+		featureId = pair.FeatureID
+		tagIds = append(tagIds, pair.TagID)
+	}
+	return newAuditSnapshot(banner, featureId, tagIds)
+}
+
+// auditDiff returns the fields whose values differ between the snapshots.
+func auditDiff(before, after *auditSnapshot) (map[string]auditChange, error) {
+	beforeFields, err := snapshotFields(before)
+	if err != nil {
+		return nil, err
+	}
+	afterFields, err := snapshotFields(after)
+	if err != nil {
+		return nil, err
+	}
+
+	diff := make(map[string]auditChange)
+	for _, fields := range []map[string]json.RawMessage{beforeFields, afterFields} {
+		for name := range fields {
+			if _, ok := diff[name]; ok {
+				continue
+			}
+			change := auditChange{Before: beforeFields[name], After: afterFields[name]}
+			if change.Before != nil && change.After != nil && bytes.Equal(change.Before, change.After) {
+				continue
+			}
+			diff[name] = change
+		}
+	}
+	return diff, nil
+}
+
+// snapshotFields splits a snapshot into compacted JSON values by field name.
+func snapshotFields(snapshot *auditSnapshot) (map[string]json.RawMessage, error) {
+	if snapshot == nil {
+		return nil, nil
+	}
+	encoded, err := json.Marshal(snapshot)
+	if err != nil {
+		return nil, err
+	}
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(encoded, &fields); err != nil {
+		return nil, err
+	}
+	return fields, nil
+}
+
+// recordAudit writes an audit event for the request in tx, so the event is
+// committed or rolled back together with the change it describes.
+func recordAudit(tx *gorm.DB, ctx echo.Context, action string, bannerID uint, before, after *auditSnapshot) error {
+	return writeAuditEvent(tx, db.AuditEvent{
+		ActorTokenID: middleware.TokenID(ctx),
+		ActorRole:    middleware.Role(ctx),
+		Action:       action,
+		BannerID:     bannerID,
+		RequestID:    middleware.RequestID(ctx),
+	}, before, after)
+}
+
+// recordJobAudit writes an audit event for a change made by a background
+// job on behalf of the request that queued it.
+func recordJobAudit(tx *gorm.DB, job *db.Job, action string, bannerID uint, before, after *auditSnapshot) error {
+	return writeAuditEvent(tx, db.AuditEvent{
+		ActorTokenID: job.ActorTokenID,
+		ActorRole:    job.ActorRole,
+		Action:       action,
+		BannerID:     bannerID,
+		RequestID:    job.RequestID,
+	}, before, after)
+}
+
+func writeAuditEvent(tx *gorm.DB, event db.AuditEvent, before, after *auditSnapshot) error {
+	diff, err := auditDiff(before, after)
+	if err != nil {
+		return err
+	}
+	if event.Diff, err = json.Marshal(diff); err != nil {
+		return err
+	}
+	return tx.Create(&event).Error
+}
+
+func (s *Server) GetAudit(ctx echo.Context, params generated.GetAuditParams) error {
+	logger := middleware.Logger(ctx)
+
+	limit := defaultAuditLimit
+	if params.Limit != nil {
+		if *params.Limit <= 0 || *params.Limit > maxAuditLimit {
+			return echo.NewHTTPError(http.StatusBadRequest, "Limit must be between 1 and 1000")
+		}
+		limit = *params.Limit
+	}
+	if params.From != nil && params.To != nil && !params.From.Before(*params.To) {
+		return echo.NewHTTPError(http.StatusBadRequest, "from must be before to")
+	}
+
+	query := s.requestDB(ctx).Model(&db.AuditEvent{}).Order("id desc").Limit(limit)
+	if params.BannerId != nil {
+		query = query.Where("banner_id = ?", *params.BannerId)
+	}
+	if params.Actor != nil {
+		query = query.Where("actor_token_id = ?", *params.Actor)
+	}
+	if params.From != nil {
+		query = query.Where("created_at >= ?", *params.From)
+	}
+	if params.To != nil {
+		query = query.Where("created_at < ?", *params.To)
+	}
+	if params.Offset != nil {
+		query = query.Offset(*params.Offset)
+	}
+
+	var events []db.AuditEvent
+	if err := query.Find(&events).Error; err != nil {
+		logger.Error("Failed to fetch audit events", "error", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to fetch audit events")
+	}
+
+	response := make([]AuditEventResponse, len(events))
+	for i, event := range events {
+		response[i] = AuditEventResponse{
+			ID:           event.ID,
+			Action:       event.Action,
+			BannerID:     event.BannerID,
+			ActorTokenID: event.ActorTokenID,
+			ActorRole:    event.ActorRole,
+			Diff:         event.Diff,
+			RequestID:    event.RequestID,
+			CreatedAt:    event.CreatedAt,
+		}
+	}
+
+	logger.Info("Successfully retrieved audit events", "count", len(events))
+	return ctx.JSON(http.StatusOK, response)
+}
@@ -0,0 +1,40 @@
+package server
+
+import (
+	"avito/internal/db"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAuditDiffReportsOnlyChangedFields(t *testing.T) {
+	banner := db.Banner{Content: json.RawMessage(`{"title": "old"}`), IsActive: true}
+	before := newAuditSnapshot(banner, 1, []int{2, 1})
+
+	banner.Content = json.RawMessage(`{"title":"new"}`)
+	after := newAuditSnapshot(banner, 1, []int{1, 2})
+
+	diff, err := auditDiff(before, after)
+	require.NoError(t, err)
+	require.Len(t, diff, 1, "reordered tags and unchanged fields must not be reported")
+	assert.JSONEq(t, `{"title":"old"}`, string(diff["content"].Before))
+	assert.JSONEq(t, `{"title":"new"}`, string(diff["content"].After))
+}
+
+func TestAuditDiffOfCreateAndDelete(t *testing.T) {
+	snapshot := newAuditSnapshot(db.Banner{Content: json.RawMessage(`{}`)}, 3, []int{4})
+
+	created, err := auditDiff(nil, snapshot)
+	require.NoError(t, err)
+	assert.Len(t, created, 6)
+	assert.Nil(t, created["feature_id"].Before)
+	assert.JSONEq(t, `3`, string(created["feature_id"].After))
+
+	deleted, err := auditDiff(snapshot, nil)
+	require.NoError(t, err)
+	assert.Len(t, deleted, 6)
+	assert.JSONEq(t, `[4]`, string(deleted["tag_ids"].Before))
+	assert.Nil(t, deleted["tag_ids"].After)
+}
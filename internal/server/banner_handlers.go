@@ -3,131 +3,220 @@ package server
 import (
 	"avito/internal/db"
 	"avito/internal/generated"
-	"context"
+	"avito/internal/server/middleware"
 	"encoding/json"
 	"errors"
-	"fmt"
+	"io"
 	"log/slog"
 	"net/http"
 	"strings"
 	"time"
 
-	"github.com/go-redis/redis"
 	"github.com/labstack/echo/v4"
 	"github.com/lib/pq"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 const (
 	UniqueViolationErr = pq.ErrorCode("23505")
+
+	// maxBatchFeatures bounds the features of one PostUserBannerBatch call.
+	maxBatchFeatures = 100
 )
 
 type CustomBannerResponse struct {
-	ID        uint            `json:"banner_id"`
-	Content   json.RawMessage `json:"content"`
-	CreatedAt time.Time       `json:"created_at"`
-	UpdatedAt time.Time       `json:"updated_at"`
-	IsActive  bool            `json:"is_active"`
-	FeatureID int             `json:"feature_id"`
-	TagIds    []int           `json:"tag_ids,"`
+	ID          uint            `json:"banner_id"`
+	Content     json.RawMessage `json:"content"`
+	CreatedAt   time.Time       `json:"created_at"`
+	UpdatedAt   time.Time       `json:"updated_at"`
+	IsActive    bool            `json:"is_active"`
+	ActiveFrom  *time.Time      `json:"active_from"`
+	ActiveUntil *time.Time      `json:"active_until"`
+	FeatureID   int             `json:"feature_id"`
+	TagIds      []int           `json:"tag_ids,"`
+	DeletedAt   *time.Time      `json:"deleted_at,omitempty"`
 }
 
 type BannerPostResponseCreated struct {
 	BannerId *uint `json:"banner_id,omitempty"`
 }
 
+// bannerWithTags is a banner row together with its feature and tag ids,
+// aggregated from banner_feature_tags in the same query.
+type bannerWithTags struct {
+	db.Banner
+	FeatureID int
+	TagIDs    pq.Int64Array `gorm:"type:integer[]"`
+}
+
 func (s *Server) GetBanner(ctx echo.Context, params generated.GetBannerParams) error {
-	slog.Info("Starting GetBanner request", "params", params)
+	logger := middleware.Logger(ctx)
+	logger.Info("Starting GetBanner request", "params", params)
 
-	var banners []db.Banner
+	if params.Deleted != nil && *params.Deleted {
+		return s.listBanners(ctx, s.deletedBannersQuery(ctx, params), params)
+	}
 
-	query := s.DB.Model(&db.Banner{})
+	// Filters are applied through EXISTS rather than on the aggregated join,
+	// so a banner matched by one tag is still returned with all of its tags.
+	query := s.bannersWithTagsQuery(ctx)
 
 	if params.FeatureId != nil && params.TagId != nil {
-		slog.Debug("Filtering banners by both feature and tag", "feature", *params.FeatureId, "tag", *params.TagId)
-		query = query.Joins("join banner_feature_tags on banner_feature_tags.banner_id = banners.id").
-			Where("banner_feature_tags.feature_id = ? AND banner_feature_tags.tag_id = ?", *params.FeatureId, *params.TagId)
+		logger.Debug("Filtering banners by both feature and tag", "feature", *params.FeatureId, "tag", *params.TagId)
+		query = query.Where("EXISTS (SELECT 1 FROM banner_feature_tags f WHERE f.banner_id = banners.id AND f.feature_id = ? AND f.tag_id = ?)", *params.FeatureId, *params.TagId)
 	} else if params.FeatureId != nil {
-		slog.Debug("Filtering banners by feature", "feature", *params.FeatureId)
-		query = query.Joins("join banner_feature_tags on banner_feature_tags.banner_id = banners.id").
-			Where("banner_feature_tags.feature_id = ?", *params.FeatureId)
+		logger.Debug("Filtering banners by feature", "feature", *params.FeatureId)
+		query = query.Where("EXISTS (SELECT 1 FROM banner_feature_tags f WHERE f.banner_id = banners.id AND f.feature_id = ?)", *params.FeatureId)
 	} else if params.TagId != nil {
-		slog.Debug("Filtering banners by tag", "tag", *params.TagId)
-		query = query.Joins("join banner_feature_tags on banner_feature_tags.banner_id = banners.id").
-			Where("banner_feature_tags.tag_id = ?", *params.TagId)
+		logger.Debug("Filtering banners by tag", "tag", *params.TagId)
+		query = query.Where("EXISTS (SELECT 1 FROM banner_feature_tags f WHERE f.banner_id = banners.id AND f.tag_id = ?)", *params.TagId)
+	}
+
+	return s.listBanners(ctx, query, params)
+}
+
+// bannersWithTagsQuery selects live banners ordered by id, each with its
+// feature and tag ids, for scanning into bannerWithTags.
+func (s *Server) bannersWithTagsQuery(ctx echo.Context) *gorm.DB {
+	return s.requestDB(ctx).Model(&db.Banner{}).
+		Select("banners.*, COALESCE(MAX(bft.feature_id), 0) AS feature_id, " +
+			"array_agg(bft.tag_id ORDER BY bft.id) FILTER (WHERE bft.tag_id IS NOT NULL) AS tag_ids").
+		Joins("left join banner_feature_tags bft on bft.banner_id = banners.id").
+		Group("banners.id").
+		Order("banners.id")
+}
+
+// deletedBannersQuery lists the trash. Deleted banners have no rows in
+// banner_feature_tags, so their feature and tags come from the latest
+// version snapshot.
+func (s *Server) deletedBannersQuery(ctx echo.Context, params generated.GetBannerParams) *gorm.DB {
+	query := s.requestDB(ctx).Unscoped().Model(&db.Banner{}).
+		Select("banners.*, COALESCE(v.feature_id, 0) AS feature_id, v.tag_ids AS tag_ids").
+		Joins("left join lateral (select feature_id, tag_ids from banner_versions where banner_id = banners.id order by version desc limit 1) v on true").
+		Where("banners.deleted_at IS NOT NULL").
+		Order("banners.id")
+
+	if params.FeatureId != nil {
+		query = query.Where("v.feature_id = ?", *params.FeatureId)
+	}
+	if params.TagId != nil {
+		query = query.Where("? = ANY(v.tag_ids)", *params.TagId)
+	}
+	return query
+}
+
+// listBanners applies pagination to query and writes the page.
+func (s *Server) listBanners(ctx echo.Context, query *gorm.DB, params generated.GetBannerParams) error {
+	logger := middleware.Logger(ctx)
+
+	var banners []bannerWithTags
+
+	if params.Cursor != nil {
+		if params.Offset != nil {
+			logger.Warn("Both cursor and offset provided")
+			return echo.NewHTTPError(http.StatusBadRequest, "cursor and offset cannot be used together")
+		}
+		cursor, err := decodeCursor(*params.Cursor)
+		if err != nil {
+			logger.Warn("Invalid cursor", "cursor", *params.Cursor)
+			return echo.NewHTTPError(http.StatusBadRequest, "Invalid cursor")
+		}
+		query = query.Where("banners.id > ?", cursor.AfterID)
+		logger.Debug("Applying cursor to query", "afterID", cursor.AfterID)
 	}
 
 	if params.Limit != nil {
 		query = query.Limit(*params.Limit)
-		slog.Debug("Applying limit to query", "limit", *params.Limit)
+		logger.Debug("Applying limit to query", "limit", *params.Limit)
 	}
 	if params.Offset != nil {
 		query = query.Offset(*params.Offset)
-		slog.Debug("Applying offset to query", "offset", *params.Offset)
+		logger.Debug("Applying offset to query", "offset", *params.Offset)
 	}
 
-	if err := query.Find(&banners).Error; err != nil {
-		slog.Error("Failed to fetch banners from database", "error", err)
+	if err := query.Scan(&banners).Error; err != nil {
+		logger.Error("Failed to fetch banners from database", "error", err)
 		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to fetch banners from database")
 	}
 
 	response := make([]CustomBannerResponse, len(banners))
 	for i, banner := range banners {
 		response[i] = CustomBannerResponse{
-			ID:        banner.ID,
-			Content:   banner.Content,
-			CreatedAt: banner.CreatedAt,
-			UpdatedAt: banner.UpdatedAt,
-			IsActive:  banner.IsActive,
+			ID:          banner.ID,
+			Content:     banner.Content,
+			CreatedAt:   banner.CreatedAt,
+			UpdatedAt:   banner.UpdatedAt,
+			IsActive:    banner.IsActive,
+			ActiveFrom:  banner.ActiveFrom,
+			ActiveUntil: banner.ActiveUntil,
+			FeatureID:   banner.FeatureID,
 		}
-		var bannerFeatureTags []db.BannerFeatureTag
-		if err := s.DB.Where("banner_id = ?", banner.ID).Find(&bannerFeatureTags).Error; err != nil {
-			slog.Error("Failed to fetch banner relations", "bannerID", banner.ID, "error", err)
-			return echo.NewHTTPError(http.StatusInternalServerError, "Failed to fetch banner relations")
+		if banner.DeletedAt.Valid {
+			deletedAt := banner.DeletedAt.Time
+			response[i].DeletedAt = &deletedAt
 		}
-		for _, bft := range bannerFeatureTags {
-			if bft.FeatureID != 0 {
-				response[i].FeatureID = bft.FeatureID
-			}
-			response[i].TagIds = append(response[i].TagIds, bft.TagID)
+		for _, tagId := range banner.TagIDs {
+			response[i].TagIds = append(response[i].TagIds, int(tagId))
 		}
 	}
 
-	slog.Info("Successfully retrieved banners", "count", len(banners))
+	// A full page means there may be more banners; an empty or short page
+	// ends the listing. Offset pages get no cursor to keep the two modes apart.
+	if params.Limit != nil && params.Offset == nil && len(banners) > 0 && len(banners) == *params.Limit {
+		last := banners[len(banners)-1].ID
+		ctx.Response().Header().Set(nextCursorHeader, encodeCursor(bannerCursor{AfterID: last}))
+	}
+
+	logger.Info("Successfully retrieved banners", "count", len(banners))
 	return ctx.JSON(http.StatusOK, response)
 }
 
 func (s *Server) PostBanner(ctx echo.Context, params generated.PostBannerParams) error {
+	logger := middleware.Logger(ctx)
+
 	var jsonBody generated.PostBannerJSONBody
 	if err := ctx.Bind(&jsonBody); err != nil {
-		slog.Error("Failed to bind JSON body for new banner", "error", err)
+		logger.Error("Failed to bind JSON body for new banner", "error", err)
 		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request body")
 	}
 
 	if jsonBody.IsActive == nil || jsonBody.Content == nil || jsonBody.FeatureId == nil || jsonBody.TagIds == nil {
-		slog.Warn("Missing one or more required fields for new banner", "IsActive", jsonBody.IsActive, "Content", jsonBody.Content, "FeatureId", jsonBody.FeatureId, "TagIds", jsonBody.TagIds)
+		logger.Warn("Missing one or more required fields for new banner", "IsActive", jsonBody.IsActive, "Content", jsonBody.Content, "FeatureId", jsonBody.FeatureId, "TagIds", jsonBody.TagIds)
 		return echo.NewHTTPError(http.StatusBadRequest, "Missing required fields: IsActive, Content, FeatureId, and TagIds must be provided")
 	}
 
-	slog.Info("Starting transaction to create new banner")
-	tx := s.DB.Begin()
+	if err := validateActiveWindow(logger, jsonBody.ActiveFrom, jsonBody.ActiveUntil); err != nil {
+		return err
+	}
+
+	content := getJsonFromPointer(jsonBody.Content)
+	if err := validateBannerContent(logger, s.requestDB(ctx), *jsonBody.FeatureId, content); err != nil {
+		return err
+	}
+
+	logger.Info("Starting transaction to create new banner")
+	tx := s.requestDB(ctx).Begin()
 	if tx.Error != nil {
-		slog.Error("Failed to start transaction for new banner", "error", tx.Error)
+		logger.Error("Failed to start transaction for new banner", "error", tx.Error)
 		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to start database transaction")
 	}
 
 	banner := db.Banner{
-		IsActive: *jsonBody.IsActive,
-		Content:  getJsonFromPointer(jsonBody.Content),
+		IsActive:    *jsonBody.IsActive,
+		ActiveFrom:  jsonBody.ActiveFrom,
+		ActiveUntil: jsonBody.ActiveUntil,
+		Content:     content,
 	}
 
 	if err := tx.Create(&banner).Error; err != nil {
 		tx.Rollback()
-		slog.Error("Failed to save new banner", "error", err)
+		logger.Error("Failed to save new banner", "error", err)
 		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to save banner: "+err.Error())
 	}
 
-	slog.Info("Banner created successfully", "bannerID", banner.ID)
+	logger.Info("Banner created successfully", "bannerID", banner.ID)
+	createdTags := make([]db.BannerFeatureTag, 0, len(*jsonBody.TagIds))
 	for _, tagId := range *jsonBody.TagIds {
 		bftEntry := db.BannerFeatureTag{
 			BannerID:  banner.ID,
@@ -137,20 +226,35 @@ func (s *Server) PostBanner(ctx echo.Context, params generated.PostBannerParams)
 		if err := tx.Create(&bftEntry).Error; err != nil {
 			tx.Rollback()
 			if isDuplicateEntryError(err) {
-				slog.Warn("Attempted to create a duplicate feature tag combination", "feature", *jsonBody.FeatureId, "tag", tagId)
+				logger.Warn("Attempted to create a duplicate feature tag combination", "feature", *jsonBody.FeatureId, "tag", tagId)
 				return echo.NewHTTPError(http.StatusConflict, "Duplicate feature and tag combination")
 			}
-			slog.Error("Failed to create banner feature tag", "feature", *jsonBody.FeatureId, "tag", tagId, "error", err)
+			logger.Error("Failed to create banner feature tag", "feature", *jsonBody.FeatureId, "tag", tagId, "error", err)
 			return echo.NewHTTPError(http.StatusInternalServerError, "Failed to create banner feature tag: "+err.Error())
 		}
+		createdTags = append(createdTags, bftEntry)
+	}
+
+	if err := snapshotBannerVersion(tx, banner, *jsonBody.FeatureId, *jsonBody.TagIds); err != nil {
+		tx.Rollback()
+		logger.Error("Failed to save banner version", "bannerID", banner.ID, "error", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to save banner version: "+err.Error())
+	}
+
+	if err := recordAudit(tx, ctx, db.AuditBannerCreate, banner.ID, nil, newAuditSnapshot(banner, *jsonBody.FeatureId, *jsonBody.TagIds)); err != nil {
+		tx.Rollback()
+		logger.Error("Failed to record audit event", "bannerID", banner.ID, "error", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to record audit event: "+err.Error())
 	}
 
 	if err := tx.Commit().Error; err != nil {
-		slog.Error("Failed to commit transaction for new banner", "error", err)
+		logger.Error("Failed to commit transaction for new banner", "error", err)
 		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to commit transaction: "+err.Error())
 	}
 
-	slog.Info("Banner creation and association completed successfully", "bannerID", banner.ID)
+	s.invalidateBannerCache(ctx.Request().Context(), createdTags)
+
+	logger.Info("Banner creation and association completed successfully", "bannerID", banner.ID)
 	return ctx.JSON(http.StatusCreated, BannerPostResponseCreated{BannerId: &banner.ID})
 }
 
@@ -158,37 +262,112 @@ func isDuplicateEntryError(err error) bool {
 	return strings.Contains(err.Error(), "23505")
 }
 
+// DeleteBannerId moves the banner to the trash and removes its feature/tag
+// pairs in one transaction, so the pairs are free for new banners right
+// away. The pairs are saved as a new version first, and PostBannerIdRestore
+// brings them back from it.
 func (s *Server) DeleteBannerId(ctx echo.Context, id int, params generated.DeleteBannerIdParams) error {
-	if err := s.DB.Delete(&db.Banner{}, id).Error; err != nil {
+	logger := middleware.Logger(ctx)
+
+	tx := s.requestDB(ctx).Begin()
+	if tx.Error != nil {
+		logger.Error("Failed to start database transaction", "error", tx.Error)
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to start database transaction")
+	}
+
+	var banner db.Banner
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&banner, id).Error; err != nil {
+		tx.Rollback()
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			logger.Warn("Banner not found during delete operation", "bannerID", id)
+			return echo.NewHTTPError(http.StatusNotFound, "Banner not found")
+		}
+		logger.Error("Database error on retrieving banner", "error", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "Database error: "+err.Error())
+	}
+
+	var existingTags []db.BannerFeatureTag
+	if err := tx.Where("banner_id = ?", id).Find(&existingTags).Error; err != nil {
+		tx.Rollback()
+		logger.Error("Failed to retrieve feature and tag associations", "bannerID", id, "error", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to delete banner")
+	}
+
+	if err := snapshotBannerPairs(tx, banner, existingTags); err != nil {
+		tx.Rollback()
+		logger.Error("Failed to save banner version", "bannerID", id, "error", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to save banner version: "+err.Error())
+	}
+
+	if err := tx.Where("banner_id = ?", id).Delete(&db.BannerFeatureTag{}).Error; err != nil {
+		tx.Rollback()
+		logger.Error("Failed to delete banner feature tags", "bannerID", id, "error", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to delete banner")
+	}
+
+	if err := tx.Delete(&banner).Error; err != nil {
+		tx.Rollback()
+		logger.Error("Failed to delete banner", "bannerID", id, "error", err)
 		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to delete banner")
 	}
+
+	if err := recordAudit(tx, ctx, db.AuditBannerDelete, banner.ID, pairsSnapshot(banner, existingTags), nil); err != nil {
+		tx.Rollback()
+		logger.Error("Failed to record audit event", "bannerID", id, "error", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to record audit event: "+err.Error())
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		logger.Error("Failed to commit transaction", "error", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to commit transaction: "+err.Error())
+	}
+
+	logger.Info("Banner deleted", "bannerID", id, "pairs", len(existingTags))
+	s.invalidateBannerCache(ctx.Request().Context(), existingTags)
 	return ctx.NoContent(http.StatusNoContent)
 }
 
 func (s *Server) PatchBannerId(ctx echo.Context, id int, params generated.PatchBannerIdParams) error {
+	logger := middleware.Logger(ctx)
+
+	body, err := io.ReadAll(ctx.Request().Body)
+	if err != nil {
+		logger.Error("Failed to read request body", "error", err)
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid input")
+	}
+
+	// The body is decoded twice: into the generated struct for the values and
+	// into a raw map to tell an explicit null, which clears the activation
+	// window, from an omitted field, which keeps it.
 	var jsonBody generated.PatchBannerIdJSONBody
-	if err := ctx.Bind(&jsonBody); err != nil {
-		slog.Error("Failed to bind JSON body", "error", err)
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(body, &jsonBody); err != nil {
+		logger.Error("Failed to bind JSON body", "error", err)
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid input")
+	}
+	if err := json.Unmarshal(body, &fields); err != nil {
+		logger.Error("Failed to bind JSON body", "error", err)
 		return echo.NewHTTPError(http.StatusBadRequest, "Invalid input")
 	}
 
-	slog.Info("Starting transaction for patching banner", "bannerID", id)
-	tx := s.DB.Begin()
+	logger.Info("Starting transaction for patching banner", "bannerID", id)
+	tx := s.requestDB(ctx).Begin()
 	if tx.Error != nil {
-		slog.Error("Failed to start database transaction", "error", tx.Error)
+		logger.Error("Failed to start database transaction", "error", tx.Error)
 		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to start database transaction")
 	}
 
 	var banner db.Banner
-	if err := tx.First(&banner, id).Error; err != nil {
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&banner, id).Error; err != nil {
 		tx.Rollback()
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			slog.Warn("Banner not found during patch operation", "bannerID", id)
+			logger.Warn("Banner not found during patch operation", "bannerID", id)
 			return echo.NewHTTPError(http.StatusNotFound, "Banner not found")
 		}
-		slog.Error("Database error on retrieving banner", "error", err)
+		logger.Error("Database error on retrieving banner", "error", err)
 		return echo.NewHTTPError(http.StatusInternalServerError, "Database error: "+err.Error())
 	}
+	original := banner
 
 	if jsonBody.IsActive != nil {
 		banner.IsActive = *jsonBody.IsActive
@@ -196,18 +375,28 @@ func (s *Server) PatchBannerId(ctx echo.Context, id int, params generated.PatchB
 	if jsonBody.Content != nil {
 		banner.Content = getJsonFromPointer(jsonBody.Content)
 	}
+	if _, ok := fields["active_from"]; ok {
+		banner.ActiveFrom = jsonBody.ActiveFrom
+	}
+	if _, ok := fields["active_until"]; ok {
+		banner.ActiveUntil = jsonBody.ActiveUntil
+	}
+	if err := validateActiveWindow(logger, banner.ActiveFrom, banner.ActiveUntil); err != nil {
+		tx.Rollback()
+		return err
+	}
 	if err := tx.Save(&banner).Error; err != nil {
 		tx.Rollback()
-		slog.Error("Failed to update banner", "bannerID", id, "error", err)
+		logger.Error("Failed to update banner", "bannerID", id, "error", err)
 		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to update banner: "+err.Error())
 	}
 
-	slog.Info("Banner updated successfully", "bannerID", id)
+	logger.Info("Banner updated successfully", "bannerID", id)
 
 	var existingTags []db.BannerFeatureTag
 	if err := tx.Where("banner_id = ?", id).Find(&existingTags).Error; err != nil {
 		tx.Rollback()
-		slog.Error("Failed to retrieve existing feature and tag associations", "bannerID", id, "error", err)
+		logger.Error("Failed to retrieve existing feature and tag associations", "bannerID", id, "error", err)
 		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to retrieve existing feature and tag associations: "+err.Error())
 	}
 
@@ -224,12 +413,20 @@ func (s *Server) PatchBannerId(ctx echo.Context, id int, params generated.PatchB
 		tagIds = *jsonBody.TagIds
 	}
 
+	if featureId != nil && (jsonBody.Content != nil || jsonBody.FeatureId != nil) {
+		if err := validateBannerContent(logger, tx, *featureId, banner.Content); err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+
 	if err := tx.Where("banner_id = ?", id).Delete(&db.BannerFeatureTag{}).Error; err != nil {
 		tx.Rollback()
-		slog.Error("Failed to delete existing banner feature tags", "bannerID", id, "error", err)
+		logger.Error("Failed to delete existing banner feature tags", "bannerID", id, "error", err)
 		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to delete existing banner feature tags: "+err.Error())
 	}
 
+	newTags := make([]db.BannerFeatureTag, 0, len(tagIds))
 	if featureId != nil {
 		for _, tagId := range tagIds {
 			bftEntry := db.BannerFeatureTag{
@@ -240,74 +437,143 @@ func (s *Server) PatchBannerId(ctx echo.Context, id int, params generated.PatchB
 			if err := tx.Create(&bftEntry).Error; err != nil {
 				tx.Rollback()
 				if isDuplicateEntryError(err) {
-					slog.Warn("Duplicate feature and tag combination detected", "feature", *featureId, "tag", tagId)
+					logger.Warn("Duplicate feature and tag combination detected", "feature", *featureId, "tag", tagId)
 					return echo.NewHTTPError(http.StatusConflict, "Duplicate feature and tag combination")
 				}
-				slog.Error("Failed to create new banner feature tag", "error", err)
+				logger.Error("Failed to create new banner feature tag", "error", err)
 				return echo.NewHTTPError(http.StatusInternalServerError, "Failed to create new banner feature tag: "+err.Error())
 			}
+			newTags = append(newTags, bftEntry)
 		}
 	}
 
+	var snapshotFeatureId int
+	if featureId != nil {
+		snapshotFeatureId = *featureId
+	}
+	if err := snapshotBannerVersion(tx, banner, snapshotFeatureId, tagIds); err != nil {
+		tx.Rollback()
+		logger.Error("Failed to save banner version", "bannerID", id, "error", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to save banner version: "+err.Error())
+	}
+
+	before := pairsSnapshot(original, existingTags)
+	after := newAuditSnapshot(banner, snapshotFeatureId, tagIds)
+	if err := recordAudit(tx, ctx, db.AuditBannerUpdate, banner.ID, before, after); err != nil {
+		tx.Rollback()
+		logger.Error("Failed to record audit event", "bannerID", id, "error", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to record audit event: "+err.Error())
+	}
+
 	if err := tx.Commit().Error; err != nil {
-		slog.Error("Failed to commit transaction", "bannerID", id, "error", err)
+		logger.Error("Failed to commit transaction", "bannerID", id, "error", err)
 		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to commit transaction: "+err.Error())
 	}
 
-	slog.Info("Banner patch operation completed successfully", "bannerID", id)
+	s.invalidateBannerCache(ctx.Request().Context(), append(existingTags, newTags...))
+
+	logger.Info("Banner patch operation completed successfully", "bannerID", id)
 	return ctx.String(http.StatusOK, "OK")
 }
 
 func (s *Server) GetUserBanner(ctx echo.Context, params generated.GetUserBannerParams) error {
-	slog.Info("Attempting to retrieve banner", "featureID", params.FeatureId, "tagID", params.TagId)
-
-	redisKey := fmt.Sprintf("banner:%d:%d", params.FeatureId, params.TagId)
-
-	if params.UseLastRevision == nil || !*params.UseLastRevision {
-		slog.Info("Checking cache for banner", "redisKey", redisKey)
-		result, err := s.Redis.Get(context.Background(), redisKey).Result()
-		if err == nil {
-			slog.Info("Cache hit for banner", "redisKey", redisKey)
-			return ctx.JSONBlob(http.StatusOK, []byte(result))
-		} else if err != redis.Nil {
-			slog.Error("Redis error occurred", "error", err)
-		} else {
-			slog.Info("Cache miss for banner", "redisKey", redisKey)
-		}
+	logger := middleware.Logger(ctx)
+	logger.Info("Attempting to retrieve banner", "featureID", params.FeatureId, "tagID", params.TagId)
+
+	redisKey := bannerCacheKey(params.FeatureId, params.TagId)
+	isAdmin := middleware.IsAdmin(ctx)
+
+	if tagID, ok := middleware.PinnedTagID(ctx); ok && !isAdmin && tagID != params.TagId {
+		logger.Warn("Token is not allowed to query this tag", "tagID", params.TagId, "allowedTagID", tagID)
+		return echo.NewHTTPError(http.StatusForbidden, "No access")
 	}
 
-	var banner db.Banner
-	if err := s.DB.Model(&db.Banner{}).Joins("join banner_feature_tags on banner_feature_tags.banner_id = banners.id").
-		Where("banner_feature_tags.feature_id = ? AND banner_feature_tags.tag_id = ?", params.FeatureId, params.TagId).First(&banner).Error; err != nil {
+	var banner *cachedBanner
+	var err error
+	if params.UseLastRevision != nil && *params.UseLastRevision {
+		generation := s.localCache.currentGeneration()
+		if banner, err = s.loadBanner(ctx.Request().Context(), logger, redisKey, params.FeatureId, params.TagId); err == nil {
+			s.localCache.set(redisKey, banner, generation)
+		}
+		middleware.SetCacheOutcome(ctx, cacheOutcomeBypass)
+	} else {
+		var outcome string
+		banner, outcome, err = s.lookupBanner(ctx.Request().Context(), logger, redisKey, params.FeatureId, params.TagId)
+		middleware.SetCacheOutcome(ctx, outcome)
+	}
+	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			slog.Warn("Banner not found in database", "featureID", params.FeatureId, "tagID", params.TagId)
+			logger.Warn("Banner not found in database", "featureID", params.FeatureId, "tagID", params.TagId)
 			return echo.NewHTTPError(http.StatusNotFound, "Banner not found or is not active")
 		}
-		slog.Error("Database error when fetching banner", "error", err)
+		logger.Error("Database error when fetching banner", "error", err)
 		return echo.NewHTTPError(http.StatusInternalServerError, "Database error: "+err.Error())
 	}
 
-	slog.Info("Banner retrieved from database", "bannerID", banner.ID)
+	if !banner.visibleAt(time.Now()) && !isAdmin {
+		logger.Warn("Banner is not active", "featureID", params.FeatureId, "tagID", params.TagId)
+		return echo.NewHTTPError(http.StatusNotFound, "Banner not found or is not active")
+	}
+
+	return ctx.JSONBlob(http.StatusOK, banner.Content)
+}
+
+// PostUserBannerBatch returns the banners of several features for one tag,
+// keyed by feature id. Features without a visible banner are left out, so
+// one missing banner does not fail the batch.
+func (s *Server) PostUserBannerBatch(ctx echo.Context, params generated.PostUserBannerBatchParams) error {
+	logger := middleware.Logger(ctx)
+
+	var jsonBody generated.PostUserBannerBatchJSONBody
+	if err := ctx.Bind(&jsonBody); err != nil {
+		logger.Error("Failed to bind JSON body for banner batch", "error", err)
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request body")
+	}
+	if len(jsonBody.FeatureIds) == 0 || len(jsonBody.FeatureIds) > maxBatchFeatures {
+		logger.Warn("Invalid number of features in banner batch", "count", len(jsonBody.FeatureIds))
+		return echo.NewHTTPError(http.StatusBadRequest, "feature_ids must contain between 1 and 100 features")
+	}
+
+	isAdmin := middleware.IsAdmin(ctx)
+	if tagID, ok := middleware.PinnedTagID(ctx); ok && !isAdmin && tagID != jsonBody.TagId {
+		logger.Warn("Token is not allowed to query this tag", "tagID", jsonBody.TagId, "allowedTagID", tagID)
+		return echo.NewHTTPError(http.StatusForbidden, "No access")
+	}
 
-	var tags []db.BannerFeatureTag
-	if err := s.DB.Where("banner_id = ?", banner.ID).Find(&tags).Error; err != nil {
-		slog.Error("Failed to fetch banner feature tags from database", "error", err)
-		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to fetch banner feature tags: "+err.Error())
+	featureIds := make([]int, 0, len(jsonBody.FeatureIds))
+	seen := make(map[int]bool, len(jsonBody.FeatureIds))
+	for _, featureId := range jsonBody.FeatureIds {
+		if !seen[featureId] {
+			seen[featureId] = true
+			featureIds = append(featureIds, featureId)
+		}
 	}
 
-	respBytes, err := json.Marshal(banner.Content)
+	banners, outcome, err := s.lookupBanners(ctx.Request().Context(), logger, jsonBody.TagId, featureIds)
+	middleware.SetCacheOutcome(ctx, outcome)
 	if err != nil {
-		slog.Error("Failed to serialize banner response for caching", "error", err)
-		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to serialize response: "+err.Error())
+		logger.Error("Database error when fetching banners", "error", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "Database error: "+err.Error())
 	}
 
-	if err := s.Redis.Set(context.Background(), redisKey, respBytes, 5*time.Minute).Err(); err != nil {
-		slog.Error("Failed to cache banner data in Redis", "error", err)
-	} else {
-		slog.Info("Banner data cached in Redis successfully", "redisKey", redisKey)
+	now := time.Now()
+	response := make(map[int]json.RawMessage, len(banners))
+	for featureId, banner := range banners {
+		if banner.visibleAt(now) || isAdmin {
+			response[featureId] = banner.Content
+		}
 	}
 
-	return ctx.JSON(http.StatusOK, banner.Content)
+	logger.Info("Retrieved banner batch", "tagID", jsonBody.TagId, "requested", len(featureIds), "returned", len(response))
+	return ctx.JSON(http.StatusOK, response)
+}
+
+func validateActiveWindow(logger *slog.Logger, activeFrom, activeUntil *time.Time) error {
+	if activeFrom != nil && activeUntil != nil && !activeFrom.Before(*activeUntil) {
+		logger.Warn("Invalid activation window", "activeFrom", activeFrom, "activeUntil", activeUntil)
+		return echo.NewHTTPError(http.StatusBadRequest, "active_from must be before active_until")
+	}
+	return nil
 }
 
 func getJsonFromPointer(p *map[string]interface{}) json.RawMessage {
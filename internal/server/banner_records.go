@@ -0,0 +1,279 @@
+package server
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+)
+
+const (
+	formatNDJSON = "ndjson"
+	formatCSV    = "csv"
+
+	// maxRecordLineSize bounds a single NDJSON line, i.e. one banner.
+	maxRecordLineSize = 4 << 20
+)
+
+// recordColumns is the CSV header, in the order the export writes it. The
+// import matches columns by name, so they may come in any order.
+var recordColumns = []string{"banner_id", "feature_id", "tag_ids", "is_active", "active_from", "active_until", "created_at", "updated_at", "content"}
+
+// bannerRecord is one banner in an export or import. Fields the import
+// requires are pointers, so a missing field can be told from a zero value.
+type bannerRecord struct {
+	BannerID    uint            `json:"banner_id,omitempty"`
+	FeatureID   *int            `json:"feature_id"`
+	TagIDs      *[]int          `json:"tag_ids"`
+	IsActive    *bool           `json:"is_active"`
+	ActiveFrom  *time.Time      `json:"active_from"`
+	ActiveUntil *time.Time      `json:"active_until"`
+	CreatedAt   *time.Time      `json:"created_at,omitempty"`
+	UpdatedAt   *time.Time      `json:"updated_at,omitempty"`
+	Content     json.RawMessage `json:"content"`
+}
+
+// errInvalidRecord wraps errors in the data of a single record, as opposed
+// to errors reading the input.
+var errInvalidRecord = errors.New("invalid record")
+
+type recordWriter interface {
+	Write(record bannerRecord) error
+	Flush() error
+}
+
+type recordReader interface {
+	// Read returns the next record and the line it starts on, or io.EOF.
+	Read() (bannerRecord, int, error)
+}
+
+func newRecordWriter(format string, w io.Writer) (recordWriter, error) {
+	switch format {
+	case formatNDJSON:
+		buffered := bufio.NewWriter(w)
+		return &ndjsonWriter{w: buffered, encoder: json.NewEncoder(buffered)}, nil
+	case formatCSV:
+		writer := csv.NewWriter(w)
+		if err := writer.Write(recordColumns); err != nil {
+			return nil, err
+		}
+		return &csvWriter{w: writer}, nil
+	}
+	return nil, fmt.Errorf("unknown format %q", format)
+}
+
+func newRecordReader(format string, r io.Reader) (recordReader, error) {
+	switch format {
+	case formatNDJSON:
+		scanner := bufio.NewScanner(r)
+		scanner.Buffer(make([]byte, 64*1024), maxRecordLineSize)
+		return &ndjsonReader{scanner: scanner}, nil
+	case formatCSV:
+		return newCSVReader(r)
+	}
+	return nil, fmt.Errorf("unknown format %q", format)
+}
+
+type ndjsonWriter struct {
+	w       *bufio.Writer
+	encoder *json.Encoder
+}
+
+func (n *ndjsonWriter) Write(record bannerRecord) error {
+	return n.encoder.Encode(record)
+}
+
+func (n *ndjsonWriter) Flush() error {
+	return n.w.Flush()
+}
+
+type ndjsonReader struct {
+	scanner *bufio.Scanner
+	line    int
+}
+
+// Read skips blank lines, so a trailing newline is not a record.
+func (n *ndjsonReader) Read() (bannerRecord, int, error) {
+	for n.scanner.Scan() {
+		n.line++
+		data := bytes.TrimSpace(n.scanner.Bytes())
+		if len(data) == 0 {
+			continue
+		}
+
+		var record bannerRecord
+		decoder := json.NewDecoder(bytes.NewReader(data))
+		decoder.DisallowUnknownFields()
+		if err := decoder.Decode(&record); err != nil {
+			return record, n.line, fmt.Errorf("%w: %v", errInvalidRecord, err)
+		}
+		return record, n.line, nil
+	}
+	if err := n.scanner.Err(); err != nil {
+		return bannerRecord{}, n.line + 1, err
+	}
+	return bannerRecord{}, n.line, io.EOF
+}
+
+type csvWriter struct {
+	w *csv.Writer
+}
+
+func (c *csvWriter) Write(record bannerRecord) error {
+	tagIDs := []int{}
+	if record.TagIDs != nil {
+		tagIDs = *record.TagIDs
+	}
+	encodedTags, err := json.Marshal(tagIDs)
+	if err != nil {
+		return err
+	}
+
+	var featureID, isActive string
+	if record.FeatureID != nil {
+		featureID = strconv.Itoa(*record.FeatureID)
+	}
+	if record.IsActive != nil {
+		isActive = strconv.FormatBool(*record.IsActive)
+	}
+	return c.w.Write([]string{
+		strconv.FormatUint(uint64(record.BannerID), 10),
+		featureID,
+		string(encodedTags),
+		isActive,
+		formatRecordTime(record.ActiveFrom),
+		formatRecordTime(record.ActiveUntil),
+		formatRecordTime(record.CreatedAt),
+		formatRecordTime(record.UpdatedAt),
+		string(record.Content),
+	})
+}
+
+func (c *csvWriter) Flush() error {
+	c.w.Flush()
+	return c.w.Error()
+}
+
+type csvReader struct {
+	r       *csv.Reader
+	columns map[string]int
+}
+
+// newCSVReader reads the header. Unknown columns are rejected, so a typo in
+// a column name does not silently drop its values.
+func newCSVReader(r io.Reader) (*csvReader, error) {
+	reader := csv.NewReader(r)
+	header, err := reader.Read()
+	if err == io.EOF {
+		return nil, fmt.Errorf("%w: missing CSV header", errInvalidRecord)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", errInvalidRecord, err)
+	}
+
+	known := make(map[string]bool, len(recordColumns))
+	for _, column := range recordColumns {
+		known[column] = true
+	}
+	columns := make(map[string]int, len(header))
+	for i, column := range header {
+		if !known[column] {
+			return nil, fmt.Errorf("%w: unknown CSV column %q", errInvalidRecord, column)
+		}
+		columns[column] = i
+	}
+	return &csvReader{r: reader, columns: columns}, nil
+}
+
+func (c *csvReader) Read() (bannerRecord, int, error) {
+	fields, err := c.r.Read()
+	line, _ := c.r.FieldPos(0)
+	if err == io.EOF {
+		return bannerRecord{}, line, io.EOF
+	}
+	var parseErr *csv.ParseError
+	if errors.As(err, &parseErr) {
+		return bannerRecord{}, parseErr.StartLine, fmt.Errorf("%w: %v", errInvalidRecord, err)
+	}
+	if err != nil {
+		return bannerRecord{}, line, err
+	}
+
+	record, err := c.parse(fields)
+	if err != nil {
+		return record, line, fmt.Errorf("%w: %v", errInvalidRecord, err)
+	}
+	return record, line, nil
+}
+
+func (c *csvReader) parse(fields []string) (bannerRecord, error) {
+	var record bannerRecord
+	value := func(column string) string {
+		if i, ok := c.columns[column]; ok {
+			return fields[i]
+		}
+		return ""
+	}
+
+	if raw := value("banner_id"); raw != "" {
+		bannerID, err := strconv.ParseUint(raw, 10, 0)
+		if err != nil {
+			return record, fmt.Errorf("banner_id: %v", err)
+		}
+		record.BannerID = uint(bannerID)
+	}
+	if raw := value("feature_id"); raw != "" {
+		featureID, err := strconv.Atoi(raw)
+		if err != nil {
+			return record, fmt.Errorf("feature_id: %v", err)
+		}
+		record.FeatureID = &featureID
+	}
+	if raw := value("tag_ids"); raw != "" {
+		var tagIDs []int
+		if err := json.Unmarshal([]byte(raw), &tagIDs); err != nil {
+			return record, fmt.Errorf("tag_ids: %v", err)
+		}
+		record.TagIDs = &tagIDs
+	}
+	if raw := value("is_active"); raw != "" {
+		isActive, err := strconv.ParseBool(raw)
+		if err != nil {
+			return record, fmt.Errorf("is_active: %v", err)
+		}
+		record.IsActive = &isActive
+	}
+	for _, field := range []struct {
+		column string
+		target **time.Time
+	}{
+		{"active_from", &record.ActiveFrom},
+		{"active_until", &record.ActiveUntil},
+		{"created_at", &record.CreatedAt},
+		{"updated_at", &record.UpdatedAt},
+	} {
+		if raw := value(field.column); raw != "" {
+			parsed, err := time.Parse(time.RFC3339Nano, raw)
+			if err != nil {
+				return record, fmt.Errorf("%s: %v", field.column, err)
+			}
+			*field.target = &parsed
+		}
+	}
+	if raw := value("content"); raw != "" {
+		record.Content = json.RawMessage(raw)
+	}
+	return record, nil
+}
+
+func formatRecordTime(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.Format(time.RFC3339Nano)
+}
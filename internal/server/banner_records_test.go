@@ -0,0 +1,127 @@
+package server
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testRecord(id uint, content string) bannerRecord {
+	featureID := 7
+	tagIDs := []int{1, 2}
+	isActive := true
+	activeFrom := time.Date(2024, 4, 1, 10, 0, 0, 0, time.UTC)
+	createdAt := time.Date(2024, 3, 1, 12, 30, 0, 500, time.UTC)
+	return bannerRecord{
+		BannerID:   id,
+		FeatureID:  &featureID,
+		TagIDs:     &tagIDs,
+		IsActive:   &isActive,
+		ActiveFrom: &activeFrom,
+		CreatedAt:  &createdAt,
+		UpdatedAt:  &createdAt,
+		Content:    json.RawMessage(content),
+	}
+}
+
+func TestRecordsRoundTrip(t *testing.T) {
+	records := []bannerRecord{testRecord(1, `{"title":"a, \"quoted\""}`), testRecord(2, `{"title":"b"}`)}
+
+	for _, format := range []string{formatNDJSON, formatCSV} {
+		t.Run(format, func(t *testing.T) {
+			var buf bytes.Buffer
+			writer, err := newRecordWriter(format, &buf)
+			require.NoError(t, err)
+			for _, record := range records {
+				require.NoError(t, writer.Write(record))
+			}
+			require.NoError(t, writer.Flush())
+
+			reader, err := newRecordReader(format, &buf)
+			require.NoError(t, err)
+			for i, want := range records {
+				got, line, err := reader.Read()
+				require.NoError(t, err)
+				if format == formatCSV {
+					assert.Equal(t, i+2, line, "the CSV header is line 1")
+				} else {
+					assert.Equal(t, i+1, line)
+				}
+				assert.Equal(t, want.BannerID, got.BannerID)
+				assert.Equal(t, *want.FeatureID, *got.FeatureID)
+				assert.Equal(t, *want.TagIDs, *got.TagIDs)
+				assert.Equal(t, *want.IsActive, *got.IsActive)
+				assert.True(t, want.ActiveFrom.Equal(*got.ActiveFrom))
+				assert.Nil(t, got.ActiveUntil)
+				assert.True(t, want.CreatedAt.Equal(*got.CreatedAt))
+				assert.JSONEq(t, string(want.Content), string(got.Content))
+			}
+			_, _, err = reader.Read()
+			assert.Equal(t, io.EOF, err)
+		})
+	}
+}
+
+func TestNDJSONReaderSkipsBlankLinesAndRejectsUnknownFields(t *testing.T) {
+	reader, err := newRecordReader(formatNDJSON, strings.NewReader("\n{\"feature_id\": 1}\n\n{\"feature\": 1}\n"))
+	require.NoError(t, err)
+
+	record, line, err := reader.Read()
+	require.NoError(t, err)
+	assert.Equal(t, 2, line)
+	assert.Equal(t, 1, *record.FeatureID)
+	assert.Nil(t, record.TagIDs)
+
+	_, line, err = reader.Read()
+	assert.ErrorIs(t, err, errInvalidRecord)
+	assert.Equal(t, 4, line)
+}
+
+func TestCSVReaderMatchesColumnsByName(t *testing.T) {
+	input := "content,tag_ids,feature_id,is_active\n\"{\"\"title\"\":\"\"x\"\"}\",\"[3]\",5,false\n"
+	reader, err := newRecordReader(formatCSV, strings.NewReader(input))
+	require.NoError(t, err)
+
+	record, _, err := reader.Read()
+	require.NoError(t, err)
+	assert.Equal(t, 5, *record.FeatureID)
+	assert.Equal(t, []int{3}, *record.TagIDs)
+	assert.False(t, *record.IsActive)
+	assert.JSONEq(t, `{"title":"x"}`, string(record.Content))
+	assert.Nil(t, record.CreatedAt)
+}
+
+func TestCSVReaderRejectsInvalidInput(t *testing.T) {
+	_, err := newRecordReader(formatCSV, strings.NewReader("feature_id,tags\n"))
+	assert.ErrorIs(t, err, errInvalidRecord)
+
+	_, err = newRecordReader(formatCSV, strings.NewReader(""))
+	assert.ErrorIs(t, err, errInvalidRecord)
+
+	reader, err := newRecordReader(formatCSV, strings.NewReader("feature_id,is_active\n1,true\nx,true\n"))
+	require.NoError(t, err)
+	_, _, err = reader.Read()
+	require.NoError(t, err)
+	_, line, err := reader.Read()
+	assert.ErrorIs(t, err, errInvalidRecord)
+	assert.Equal(t, 3, line)
+}
+
+func TestImportValidationKeepsServerErrors(t *testing.T) {
+	var lineErr *importLineError
+	require.ErrorAs(t, importValidation(echo.NewHTTPError(http.StatusBadRequest, "active_from must be before active_until")), &lineErr)
+	assert.Equal(t, importInvalid, lineErr.status)
+	assert.Equal(t, "active_from must be before active_until", lineErr.message)
+
+	serverErr := echo.NewHTTPError(http.StatusInternalServerError, "Failed to load feature schema")
+	assert.Equal(t, serverErr, importValidation(serverErr))
+	assert.Nil(t, importValidation(nil))
+}
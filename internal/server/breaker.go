@@ -0,0 +1,223 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+)
+
+const (
+	breakerFailureThreshold = 5
+	breakerProbeInterval    = 5 * time.Second
+
+	// maxPendingInvalidations bounds the keys remembered while Redis is
+	// down. Past it the breaker forgets the keys and drops every banner
+	// entry on recovery instead.
+	maxPendingInvalidations = 10000
+	bannerCacheKeyPattern   = "banner:*"
+)
+
+const (
+	BreakerClosed = "closed"
+	BreakerOpen   = "open"
+)
+
+// cacheBreaker stops the server from calling Redis after
+// breakerFailureThreshold consecutive failures, so requests are served from
+// Postgres without waiting for Redis timeouts. While open it pings Redis
+// every probe interval and closes again once Redis answers.
+//
+// Cache invalidations that could not be applied are remembered and replayed
+// before the breaker closes, so entries written before the outage cannot
+// outlive a change made during it.
+type cacheBreaker struct {
+	redis     *redis.Client
+	threshold int
+
+	mu        sync.Mutex
+	state     string
+	failures  int
+	lastError string
+	openedAt  time.Time
+	pending   map[string]struct{}
+	flushAll  bool
+}
+
+// BreakerStatus is the breaker state reported by the health endpoint.
+type BreakerStatus struct {
+	State                string     `json:"state"`
+	Failures             int        `json:"failures"`
+	LastError            string     `json:"last_error,omitempty"`
+	OpenedAt             *time.Time `json:"opened_at,omitempty"`
+	PendingInvalidations int        `json:"pending_invalidations"`
+}
+
+func newCacheBreaker(client *redis.Client, threshold int) *cacheBreaker {
+	return &cacheBreaker{
+		redis:     client,
+		threshold: threshold,
+		state:     BreakerClosed,
+		pending:   make(map[string]struct{}),
+	}
+}
+
+// allow reports whether Redis may be called.
+func (b *cacheBreaker) allow() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.state == BreakerClosed
+}
+
+// done records the outcome of a Redis call. redis.Nil is a regular miss and
+// counts as a success. A cancelled context means the client went away, not
+// that Redis failed, so it is not counted either way.
+func (b *cacheBreaker) done(err error) {
+	if errors.Is(err, context.Canceled) {
+		return
+	}
+	if err == nil || err == redis.Nil {
+		b.mu.Lock()
+		b.failures = 0
+		b.mu.Unlock()
+		return
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.failures++
+	b.lastError = err.Error()
+	if b.state == BreakerClosed && b.failures >= b.threshold {
+		b.open()
+	}
+}
+
+// trip opens the breaker right away, e.g. when Redis is down at startup.
+func (b *cacheBreaker) trip(err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.lastError = err.Error()
+	if b.state == BreakerClosed {
+		b.open()
+	}
+}
+
+func (b *cacheBreaker) open() {
+	b.state = BreakerOpen
+	b.openedAt = time.Now()
+	slog.Warn("Redis circuit breaker opened, serving banners from the database", "failures", b.failures, "error", b.lastError)
+}
+
+// deferInvalidation remembers keys whose deletion did not reach Redis.
+func (b *cacheBreaker) deferInvalidation(keys []string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.flushAll {
+		return
+	}
+	for _, key := range keys {
+		b.pending[key] = struct{}{}
+	}
+	if len(b.pending) > maxPendingInvalidations {
+		b.pending = make(map[string]struct{})
+		b.flushAll = true
+	}
+}
+
+func (b *cacheBreaker) run(interval time.Duration, stop <-chan struct{}) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+		}
+
+		if b.allow() {
+			continue
+		}
+		if err := b.probe(context.Background()); err != nil {
+			slog.Debug("Redis is still unavailable", "error", err)
+		}
+	}
+}
+
+// probe pings Redis, replays deferred invalidations and closes the breaker.
+// Invalidations deferred while replaying are picked up by the next round,
+// and the breaker only closes once nothing is left.
+func (b *cacheBreaker) probe(ctx context.Context) error {
+	if err := b.redis.Ping(ctx).Err(); err != nil {
+		b.mu.Lock()
+		b.lastError = err.Error()
+		b.mu.Unlock()
+		return err
+	}
+
+	for {
+		b.mu.Lock()
+		if len(b.pending) == 0 && !b.flushAll {
+			b.state = BreakerClosed
+			b.failures = 0
+			b.mu.Unlock()
+			slog.Info("Redis circuit breaker closed")
+			return nil
+		}
+		pending, flushAll := b.pending, b.flushAll
+		b.pending = make(map[string]struct{})
+		b.flushAll = false
+		b.mu.Unlock()
+
+		if err := b.replay(ctx, pending, flushAll); err != nil {
+			b.mu.Lock()
+			b.lastError = err.Error()
+			b.flushAll = b.flushAll || flushAll
+			for key := range pending {
+				b.pending[key] = struct{}{}
+			}
+			b.mu.Unlock()
+			return err
+		}
+		slog.Info("Replayed deferred cache invalidations", "keys", len(pending), "flushAll", flushAll)
+	}
+}
+
+func (b *cacheBreaker) replay(ctx context.Context, pending map[string]struct{}, flushAll bool) error {
+	if flushAll {
+		iter := b.redis.Scan(ctx, 0, bannerCacheKeyPattern, 1000).Iterator()
+		for iter.Next(ctx) {
+			if err := b.redis.Del(ctx, iter.Val()).Err(); err != nil {
+				return err
+			}
+		}
+		return iter.Err()
+	}
+
+	pipe := b.redis.Pipeline()
+	for key := range pending {
+		pipe.Del(ctx, key)
+	}
+	_, err := pipe.Exec(ctx)
+	return err
+}
+
+func (b *cacheBreaker) status() BreakerStatus {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	status := BreakerStatus{
+		State:                b.state,
+		Failures:             b.failures,
+		LastError:            b.lastError,
+		PendingInvalidations: len(b.pending),
+	}
+	if b.state == BreakerOpen {
+		openedAt := b.openedAt
+		status.OpenedAt = &openedAt
+	}
+	return status
+}
@@ -0,0 +1,99 @@
+package server
+
+import (
+	"avito/internal/db"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/go-redis/redis/v8"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestCacheServer(t *testing.T) (*Server, *miniredis.Miniredis) {
+	mr := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	t.Cleanup(func() { client.Close() })
+
+	return &Server{
+		Redis:          client,
+		bannerCacheTTL: time.Minute,
+		cacheBreaker:   newCacheBreaker(client, 3),
+	}, mr
+}
+
+func TestCacheBreakerOpensAfterRepeatedFailures(t *testing.T) {
+	s, mr := newTestCacheServer(t)
+	key := bannerCacheKey(1, 1)
+	require.NoError(t, s.cacheBanner(context.Background(), key, testBanner("a")))
+
+	mr.SetError("ERR simulated outage")
+	for i := 0; i < 3; i++ {
+		_, err := s.getCachedBanner(context.Background(), key)
+		require.Error(t, err)
+	}
+	assert.Equal(t, BreakerOpen, s.cacheBreaker.status().State)
+
+	calls := mr.CommandCount()
+	cached, err := s.getCachedBanner(context.Background(), key)
+	assert.NoError(t, err, "An open breaker should report a miss instead of an error")
+	assert.Nil(t, cached)
+	assert.NoError(t, s.cacheBanner(context.Background(), key, testBanner("b")))
+	assert.Equal(t, calls, mr.CommandCount(), "Redis should not be called while the breaker is open")
+}
+
+func TestCacheBreakerMissesResetFailures(t *testing.T) {
+	s, mr := newTestCacheServer(t)
+
+	mr.SetError("ERR simulated outage")
+	for i := 0; i < 2; i++ {
+		_, err := s.getCachedBanner(context.Background(), bannerCacheKey(1, 1))
+		require.Error(t, err)
+	}
+	mr.SetError("")
+
+	cached, err := s.getCachedBanner(context.Background(), bannerCacheKey(1, 1))
+	assert.ErrorIs(t, err, redis.Nil)
+	assert.Nil(t, cached)
+	assert.Equal(t, BreakerClosed, s.cacheBreaker.status().State)
+	assert.Zero(t, s.cacheBreaker.status().Failures)
+}
+
+func TestCacheBreakerReplaysInvalidationsOnRecovery(t *testing.T) {
+	s, mr := newTestCacheServer(t)
+	stale := bannerCacheKey(2, 3)
+	require.NoError(t, s.cacheBanner(context.Background(), stale, testBanner("old")))
+
+	mr.SetError("ERR simulated outage")
+	s.cacheBreaker.trip(assert.AnError)
+	s.invalidateBannerCache(context.Background(), []db.BannerFeatureTag{{FeatureID: 2, TagID: 3}})
+	assert.Equal(t, 1, s.cacheBreaker.status().PendingInvalidations)
+
+	require.Error(t, s.cacheBreaker.probe(context.Background()), "Probe should fail while Redis is down")
+	assert.Equal(t, BreakerOpen, s.cacheBreaker.status().State)
+
+	mr.SetError("")
+	require.NoError(t, s.cacheBreaker.probe(context.Background()))
+	assert.Equal(t, BreakerClosed, s.cacheBreaker.status().State)
+	assert.Zero(t, s.cacheBreaker.status().PendingInvalidations)
+	assert.False(t, mr.Exists(stale), "Entry changed during the outage must not survive recovery")
+}
+
+func TestCacheBreakerFlushesAllAfterTooManyInvalidations(t *testing.T) {
+	s, mr := newTestCacheServer(t)
+	require.NoError(t, s.cacheBanner(context.Background(), bannerCacheKey(1, 1), testBanner("a")))
+	require.NoError(t, mr.Set("unrelated", "kept"))
+
+	s.cacheBreaker.trip(assert.AnError)
+	keys := make([]string, maxPendingInvalidations+1)
+	for i := range keys {
+		keys[i] = bannerCacheKey(100, i)
+	}
+	s.cacheBreaker.deferInvalidation(keys)
+
+	require.NoError(t, s.cacheBreaker.probe(context.Background()))
+	assert.False(t, mr.Exists(bannerCacheKey(1, 1)), "Every banner entry should be dropped")
+	assert.True(t, mr.Exists("unrelated"), "Keys outside the banner cache should be kept")
+}
@@ -0,0 +1,435 @@
+package server
+
+import (
+	"avito/internal/db"
+	"avito/internal/metrics"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+)
+
+const (
+	bannerInvalidationChannel = "banner:invalidate"
+	tokenRevocationChannel    = "token:revoke"
+)
+
+// Cache outcomes of a banner lookup, reported in the access log.
+const (
+	cacheOutcomeLocalHit = "local_hit"
+	cacheOutcomeRedisHit = "redis_hit"
+	cacheOutcomeMiss     = "miss"
+	cacheOutcomeBypass   = "bypass"
+)
+
+// cachedBanner is the value stored under a banner cache key. The active flag
+// and window travel with the content so that a cache hit can be filtered for
+// regular users without going back to the database.
+type cachedBanner struct {
+	Content     json.RawMessage `json:"content"`
+	IsActive    bool            `json:"is_active"`
+	ActiveFrom  *time.Time      `json:"active_from,omitempty"`
+	ActiveUntil *time.Time      `json:"active_until,omitempty"`
+}
+
+func (c *cachedBanner) visibleAt(now time.Time) bool {
+	return db.IsVisible(c.IsActive, c.ActiveFrom, c.ActiveUntil, now)
+}
+
+func bannerCacheKey(featureID, tagID int) string {
+	return fmt.Sprintf("banner:%d:%d", featureID, tagID)
+}
+
+// getCachedBanner returns the cached banner for the key, or nil on a miss.
+// Entries that cannot be decoded are treated as misses, and so is every key
+// while the circuit breaker is open.
+func (s *Server) getCachedBanner(ctx context.Context, key string) (*cachedBanner, error) {
+	if !s.cacheBreaker.allow() {
+		s.Metrics.ObserveCache(metrics.CacheRedis, metrics.CacheBypass)
+		return nil, nil
+	}
+
+	result, err := s.Redis.Get(ctx, key).Bytes()
+	s.cacheBreaker.done(err)
+	if err == redis.Nil {
+		s.Metrics.ObserveCache(metrics.CacheRedis, metrics.CacheMiss)
+		return nil, err
+	}
+	if err != nil {
+		s.Metrics.ObserveCache(metrics.CacheRedis, metrics.CacheError)
+		return nil, err
+	}
+
+	var cached cachedBanner
+	if err := json.Unmarshal(result, &cached); err != nil || cached.Content == nil {
+		slog.Warn("Ignoring malformed banner cache entry", "redisKey", key)
+		s.Metrics.ObserveCache(metrics.CacheRedis, metrics.CacheError)
+		return nil, nil
+	}
+	s.Metrics.ObserveCache(metrics.CacheRedis, metrics.CacheHit)
+	return &cached, nil
+}
+
+// cacheBanner stores the banner under key. The TTL is clamped to the end of
+// the banner's activation window, and banners whose window is already over
+// are not cached at all.
+func (s *Server) cacheBanner(ctx context.Context, key string, banner *cachedBanner) error {
+	if !s.cacheBreaker.allow() {
+		return nil
+	}
+
+	ttl, ok := s.cacheTTL(banner)
+	if !ok {
+		return nil
+	}
+
+	value, err := json.Marshal(banner)
+	if err != nil {
+		return err
+	}
+	err = s.Redis.Set(ctx, key, value, ttl).Err()
+	s.cacheBreaker.done(err)
+	return err
+}
+
+// cacheTTL returns how long the banner may stay in Redis, or false when its
+// window is already over.
+func (s *Server) cacheTTL(banner *cachedBanner) (time.Duration, bool) {
+	ttl := s.bannerCacheTTL
+	if banner.ActiveUntil != nil {
+		remaining := time.Until(*banner.ActiveUntil)
+		if remaining <= 0 {
+			return 0, false
+		}
+		if remaining < ttl {
+			ttl = remaining
+		}
+	}
+	return ttl, true
+}
+
+// getCachedBanners reads the keys with a single MGET and returns the entries
+// found. Missing and malformed entries are left out, and nothing is read
+// while the circuit breaker is open.
+func (s *Server) getCachedBanners(ctx context.Context, keys []string) (map[string]*cachedBanner, error) {
+	found := make(map[string]*cachedBanner, len(keys))
+	if len(keys) == 0 {
+		return found, nil
+	}
+	if !s.cacheBreaker.allow() {
+		for range keys {
+			s.Metrics.ObserveCache(metrics.CacheRedis, metrics.CacheBypass)
+		}
+		return found, nil
+	}
+
+	values, err := s.Redis.MGet(ctx, keys...).Result()
+	s.cacheBreaker.done(err)
+	if err != nil {
+		for range keys {
+			s.Metrics.ObserveCache(metrics.CacheRedis, metrics.CacheError)
+		}
+		return found, err
+	}
+
+	for i, value := range values {
+		raw, ok := value.(string)
+		if !ok {
+			s.Metrics.ObserveCache(metrics.CacheRedis, metrics.CacheMiss)
+			continue
+		}
+		var cached cachedBanner
+		if err := json.Unmarshal([]byte(raw), &cached); err != nil || cached.Content == nil {
+			slog.Warn("Ignoring malformed banner cache entry", "redisKey", keys[i])
+			s.Metrics.ObserveCache(metrics.CacheRedis, metrics.CacheError)
+			continue
+		}
+		s.Metrics.ObserveCache(metrics.CacheRedis, metrics.CacheHit)
+		found[keys[i]] = &cached
+	}
+	return found, nil
+}
+
+// cacheBanners stores the banners by key in one pipeline, with the same TTL
+// rules as cacheBanner.
+func (s *Server) cacheBanners(ctx context.Context, banners map[string]*cachedBanner) error {
+	if len(banners) == 0 || !s.cacheBreaker.allow() {
+		return nil
+	}
+
+	pipe := s.Redis.Pipeline()
+	for key, banner := range banners {
+		ttl, ok := s.cacheTTL(banner)
+		if !ok {
+			continue
+		}
+		value, err := json.Marshal(banner)
+		if err != nil {
+			return err
+		}
+		pipe.Set(ctx, key, value, ttl)
+	}
+	if pipe.Len() == 0 {
+		return nil
+	}
+
+	_, err := pipe.Exec(ctx)
+	s.cacheBreaker.done(err)
+	return err
+}
+
+type bannerLookup struct {
+	banner  *cachedBanner
+	outcome string
+}
+
+// lookupBanner resolves a banner through the local cache, Redis and finally
+// the database, and reports which of them answered. Concurrent misses on the
+// same key share a single load, which logs through the logger of the request
+// that started it.
+func (s *Server) lookupBanner(ctx context.Context, logger *slog.Logger, key string, featureID, tagID int) (*cachedBanner, string, error) {
+	if s.localCache != nil {
+		if cached := s.localCache.get(key); cached != nil {
+			logger.Info("Local cache hit for banner", "redisKey", key)
+			s.Metrics.ObserveCache(metrics.CacheLocal, metrics.CacheHit)
+			return cached, cacheOutcomeLocalHit, nil
+		}
+		s.Metrics.ObserveCache(metrics.CacheLocal, metrics.CacheMiss)
+	}
+
+	// The load is shared with concurrent callers, so it must not be cut short
+	// when the request that started it goes away.
+	loadCtx := context.WithoutCancel(ctx)
+	value, err, _ := s.bannerLoads.Do(key, func() (interface{}, error) {
+		generation := s.localCache.currentGeneration()
+
+		cached, err := s.getCachedBanner(loadCtx, key)
+		if err == nil && cached != nil {
+			logger.Info("Cache hit for banner", "redisKey", key)
+			s.localCache.set(key, cached, generation)
+			return bannerLookup{banner: cached, outcome: cacheOutcomeRedisHit}, nil
+		} else if err != nil && err != redis.Nil {
+			logger.Error("Redis error occurred", "error", err)
+		} else {
+			logger.Info("Cache miss for banner", "redisKey", key)
+		}
+
+		banner, err := s.loadBanner(loadCtx, logger, key, featureID, tagID)
+		if err != nil {
+			return nil, err
+		}
+		s.localCache.set(key, banner, generation)
+		return bannerLookup{banner: banner, outcome: cacheOutcomeMiss}, nil
+	})
+	if err != nil {
+		return nil, cacheOutcomeMiss, err
+	}
+	lookup := value.(bannerLookup)
+	return lookup.banner, lookup.outcome, nil
+}
+
+// loadBanner reads the banner of a feature/tag pair from the database and
+// refreshes its Redis entry. gorm.ErrRecordNotFound is returned as is.
+func (s *Server) loadBanner(ctx context.Context, logger *slog.Logger, key string, featureID, tagID int) (*cachedBanner, error) {
+	var banner db.Banner
+	if err := s.DB.WithContext(ctx).Model(&db.Banner{}).Joins("join banner_feature_tags on banner_feature_tags.banner_id = banners.id").
+		Where("banner_feature_tags.feature_id = ? AND banner_feature_tags.tag_id = ?", featureID, tagID).First(&banner).Error; err != nil {
+		return nil, err
+	}
+
+	logger.Info("Banner retrieved from database", "bannerID", banner.ID)
+
+	cached := &cachedBanner{
+		Content:     banner.Content,
+		IsActive:    banner.IsActive,
+		ActiveFrom:  banner.ActiveFrom,
+		ActiveUntil: banner.ActiveUntil,
+	}
+	if err := s.cacheBanner(ctx, key, cached); err != nil {
+		logger.Error("Failed to cache banner data in Redis", "error", err)
+	} else {
+		logger.Info("Banner data cached in Redis successfully", "redisKey", key)
+	}
+	return cached, nil
+}
+
+// lookupBanners resolves the banners of several features for one tag: the
+// local cache first, then one MGET for the rest and a single database query
+// for what Redis does not have. Features without a banner are left out of
+// the result. The outcome names the slowest layer that had to be asked.
+func (s *Server) lookupBanners(ctx context.Context, logger *slog.Logger, tagID int, featureIDs []int) (map[int]*cachedBanner, string, error) {
+	banners := make(map[int]*cachedBanner, len(featureIDs))
+	outcome := cacheOutcomeLocalHit
+	generation := s.localCache.currentGeneration()
+
+	var redisKeys []string
+	featureByKey := make(map[string]int, len(featureIDs))
+	for _, featureID := range featureIDs {
+		key := bannerCacheKey(featureID, tagID)
+		if cached := s.localCache.get(key); cached != nil {
+			s.Metrics.ObserveCache(metrics.CacheLocal, metrics.CacheHit)
+			banners[featureID] = cached
+			continue
+		}
+		if s.localCache != nil {
+			s.Metrics.ObserveCache(metrics.CacheLocal, metrics.CacheMiss)
+		}
+		redisKeys = append(redisKeys, key)
+		featureByKey[key] = featureID
+	}
+	if len(redisKeys) == 0 {
+		return banners, outcome, nil
+	}
+
+	outcome = cacheOutcomeRedisHit
+	cached, err := s.getCachedBanners(ctx, redisKeys)
+	if err != nil {
+		logger.Error("Redis error occurred", "error", err)
+	}
+	var missing []int
+	for _, key := range redisKeys {
+		if banner, ok := cached[key]; ok {
+			banners[featureByKey[key]] = banner
+			s.localCache.set(key, banner, generation)
+			continue
+		}
+		missing = append(missing, featureByKey[key])
+	}
+	if len(missing) == 0 {
+		return banners, outcome, nil
+	}
+
+	outcome = cacheOutcomeMiss
+	var rows []struct {
+		db.Banner
+		FeatureID int
+	}
+	if err := s.DB.WithContext(ctx).Model(&db.Banner{}).
+		Select("banners.*, banner_feature_tags.feature_id AS feature_id").
+		Joins("join banner_feature_tags on banner_feature_tags.banner_id = banners.id").
+		Where("banner_feature_tags.tag_id = ? AND banner_feature_tags.feature_id IN ?", tagID, missing).
+		Scan(&rows).Error; err != nil {
+		return nil, outcome, err
+	}
+
+	loaded := make(map[string]*cachedBanner, len(rows))
+	for _, row := range rows {
+		banner := &cachedBanner{
+			Content:     row.Content,
+			IsActive:    row.IsActive,
+			ActiveFrom:  row.ActiveFrom,
+			ActiveUntil: row.ActiveUntil,
+		}
+		key := bannerCacheKey(row.FeatureID, tagID)
+		banners[row.FeatureID] = banner
+		loaded[key] = banner
+		s.localCache.set(key, banner, generation)
+	}
+	logger.Info("Banners retrieved from database", "tagID", tagID, "requested", len(missing), "found", len(rows))
+
+	if err := s.cacheBanners(ctx, loaded); err != nil {
+		logger.Error("Failed to cache banner data in Redis", "error", err)
+	}
+	return banners, outcome, nil
+}
+
+// invalidateBannerCache drops the cache entries of the given feature/tag
+// pairs. Keys are deleted one by one in a single pipeline rather than with a
+// multi-key DEL, so the call keeps working when keys land in different
+// cluster slots. The same pipeline announces the keys on
+// bannerInvalidationChannel so that every replica drops its local copy.
+func (s *Server) invalidateBannerCache(ctx context.Context, pairs []db.BannerFeatureTag) {
+	if len(pairs) == 0 {
+		return
+	}
+
+	// Invalidation runs after the change is committed and must complete even
+	// if the client has already disconnected.
+	ctx = context.WithoutCancel(ctx)
+	seen := make(map[string]struct{}, len(pairs))
+	keys := make([]string, 0, len(pairs))
+	pipe := s.Redis.Pipeline()
+	for _, pair := range pairs {
+		key := bannerCacheKey(pair.FeatureID, pair.TagID)
+		if _, ok := seen[key]; ok {
+			continue
+		}
+		seen[key] = struct{}{}
+		keys = append(keys, key)
+		pipe.Del(ctx, key)
+	}
+
+	s.localCache.remove(keys...)
+	if !s.cacheBreaker.allow() {
+		s.cacheBreaker.deferInvalidation(keys)
+		slog.Warn("Redis is unavailable, deferring banner cache invalidation", "keys", len(keys))
+		return
+	}
+	if message, err := json.Marshal(keys); err == nil {
+		pipe.Publish(ctx, bannerInvalidationChannel, message)
+	}
+
+	_, err := pipe.Exec(ctx)
+	s.cacheBreaker.done(err)
+	if err != nil {
+		s.cacheBreaker.deferInvalidation(keys)
+		slog.Error("Failed to invalidate banner cache", "keys", len(keys), "error", err)
+		return
+	}
+	slog.Info("Banner cache invalidated", "keys", len(keys))
+}
+
+// announceTokenRevocation tells every replica to drop the cached lookup of a
+// revoked token. While Redis is unavailable the announcement is lost and the
+// other replicas accept the token until their cache entry expires.
+func (s *Server) announceTokenRevocation(ctx context.Context, id string) {
+	ctx = context.WithoutCancel(ctx)
+	if !s.cacheBreaker.allow() {
+		slog.Warn("Redis is unavailable, token revocation is not announced", "tokenID", id)
+		return
+	}
+
+	err := s.Redis.Publish(ctx, tokenRevocationChannel, id).Err()
+	s.cacheBreaker.done(err)
+	if err != nil {
+		slog.Error("Failed to announce token revocation", "tokenID", id, "error", err)
+	}
+}
+
+// subscribeCacheInvalidation removes banner keys and revoked tokens announced
+// by any replica from the local caches until the server stops. It runs even
+// with the local banner cache disabled, since token lookups are always
+// cached. The subscription reconnects on its own; messages lost while
+// disconnected are covered by the cache TTLs.
+func (s *Server) subscribeCacheInvalidation() {
+	pubsub := s.Redis.Subscribe(context.Background(), bannerInvalidationChannel, tokenRevocationChannel)
+	defer pubsub.Close()
+
+	messages := pubsub.Channel()
+	for {
+		select {
+		case <-s.stop:
+			return
+		case message, ok := <-messages:
+			if !ok {
+				return
+			}
+			if message.Channel == tokenRevocationChannel {
+				s.Tokens.Forget(message.Payload)
+				continue
+			}
+			if s.localCache == nil {
+				continue
+			}
+			var keys []string
+			if err := json.Unmarshal([]byte(message.Payload), &keys); err != nil {
+				slog.Warn("Ignoring malformed cache invalidation message", "error", err)
+				continue
+			}
+			s.localCache.remove(keys...)
+		}
+	}
+}
@@ -0,0 +1,116 @@
+package server
+
+import (
+	"avito/internal/auth"
+	"avito/internal/db"
+	"avito/internal/server/middleware"
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetCachedBannersReadsHitsAndSkipsMalformedEntries(t *testing.T) {
+	s, mr := newTestCacheServer(t)
+	ctx := context.Background()
+	require.NoError(t, s.cacheBanner(ctx, bannerCacheKey(1, 9), testBanner("a")))
+	require.NoError(t, mr.Set(bannerCacheKey(2, 9), "not json"))
+
+	keys := []string{bannerCacheKey(1, 9), bannerCacheKey(2, 9), bannerCacheKey(3, 9)}
+	found, err := s.getCachedBanners(ctx, keys)
+	require.NoError(t, err)
+	require.Len(t, found, 1)
+	assert.JSONEq(t, `{"title":"a"}`, string(found[bannerCacheKey(1, 9)].Content))
+}
+
+func TestCacheBannersClampsTTLAndSkipsExpired(t *testing.T) {
+	s, mr := newTestCacheServer(t)
+	ctx := context.Background()
+
+	soon := time.Now().Add(10 * time.Second)
+	ending := testBanner("ending")
+	ending.ActiveUntil = &soon
+	past := time.Now().Add(-time.Second)
+	expired := testBanner("expired")
+	expired.ActiveUntil = &past
+
+	require.NoError(t, s.cacheBanners(ctx, map[string]*cachedBanner{
+		bannerCacheKey(1, 9): testBanner("a"),
+		bannerCacheKey(2, 9): ending,
+		bannerCacheKey(3, 9): expired,
+	}))
+
+	assert.Equal(t, time.Minute, mr.TTL(bannerCacheKey(1, 9)))
+	assert.LessOrEqual(t, mr.TTL(bannerCacheKey(2, 9)), 10*time.Second)
+	assert.False(t, mr.Exists(bannerCacheKey(3, 9)))
+}
+
+func TestGetCachedBannersBypassesOpenBreaker(t *testing.T) {
+	s, mr := newTestCacheServer(t)
+	ctx := context.Background()
+	require.NoError(t, s.cacheBanner(ctx, bannerCacheKey(1, 9), testBanner("a")))
+
+	mr.SetError("ERR simulated outage")
+	for i := 0; i < 3; i++ {
+		_, err := s.getCachedBanners(ctx, []string{bannerCacheKey(1, 9)})
+		require.Error(t, err)
+	}
+	mr.SetError("")
+
+	found, err := s.getCachedBanners(ctx, []string{bannerCacheKey(1, 9)})
+	require.NoError(t, err)
+	assert.Empty(t, found, "an open breaker must not read Redis")
+}
+
+type countingTokenStore struct {
+	lookups atomic.Int32
+}
+
+func (c *countingTokenStore) Lookup(context.Context, string) (*auth.Token, error) {
+	c.lookups.Add(1)
+	return &auth.Token{ID: "t1", Role: middleware.RoleUser}, nil
+}
+
+func TestTokenRevocationReachesOtherReplicas(t *testing.T) {
+	for name, local := range map[string]*localCache{
+		"local cache enabled":  newLocalCache(10, time.Minute),
+		"local cache disabled": nil,
+	} {
+		local := local
+		t.Run(name, func(t *testing.T) {
+			revoking, mr := newTestCacheServer(t)
+			client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+			t.Cleanup(func() { client.Close() })
+			store := &countingTokenStore{}
+			other := &Server{
+				Redis:      client,
+				Tokens:     auth.NewCachedStore(store, time.Minute),
+				localCache: local,
+				stop:       make(chan struct{}),
+			}
+			ctx := context.Background()
+
+			_, err := other.Tokens.Lookup(ctx, "raw")
+			require.NoError(t, err)
+			_, err = other.Tokens.Lookup(ctx, "raw")
+			require.NoError(t, err)
+			require.EqualValues(t, 1, store.lookups.Load(), "the second lookup is cached")
+
+			go other.subscribeCacheInvalidation()
+			t.Cleanup(func() { close(other.stop) })
+
+			// Announce until the subscription is up; each one drops the entry.
+			// A banner invalidation goes out first and must not stop the loop.
+			assert.Eventually(t, func() bool {
+				revoking.invalidateBannerCache(ctx, []db.BannerFeatureTag{{FeatureID: 1, TagID: 1}})
+				revoking.announceTokenRevocation(ctx, "t1")
+				_, err := other.Tokens.Lookup(ctx, "raw")
+				return err == nil && store.lookups.Load() > 1
+			}, time.Second, 10*time.Millisecond)
+		})
+	}
+}
@@ -0,0 +1,36 @@
+package server
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+)
+
+const nextCursorHeader = "X-Next-Cursor"
+
+var errInvalidCursor = errors.New("invalid cursor")
+
+// bannerCursor points right after the last banner of a page. Listing is
+// ordered by id, so the id alone is enough to resume.
+type bannerCursor struct {
+	AfterID uint `json:"after_id"`
+}
+
+// encodeCursor renders the cursor as an opaque URL-safe string. Clients must
+// not rely on its contents.
+func encodeCursor(c bannerCursor) string {
+	data, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func decodeCursor(raw string) (bannerCursor, error) {
+	var c bannerCursor
+	data, err := base64.RawURLEncoding.DecodeString(raw)
+	if err != nil {
+		return c, errInvalidCursor
+	}
+	if err := json.Unmarshal(data, &c); err != nil {
+		return c, errInvalidCursor
+	}
+	return c, nil
+}
@@ -2,15 +2,40 @@ package server
 
 import "avito/internal/generated"
 import "avito/internal/server/middleware"
+import "github.com/labstack/echo/v4"
 
-func RegisterHandlersWithAuth(router generated.EchoRouter, si generated.ServerInterface) {
+func RegisterHandlersWithAuth(router generated.EchoRouter, si generated.ServerInterface, auth *middleware.Auth, limiter *middleware.RateLimiter) {
 	wrapper := generated.ServerInterfaceWrapper{
 		Handler: si,
 	}
 
-	router.GET("/banner", wrapper.GetBanner, middleware.AdminMiddleware)
-	router.POST("/banner", wrapper.PostBanner, middleware.AdminMiddleware)
-	router.DELETE("/banner/:id", wrapper.DeleteBannerId, middleware.AdminMiddleware)
-	router.PATCH("/banner/:id", wrapper.PatchBannerId, middleware.AdminMiddleware)
-	router.GET("/user_banner", wrapper.GetUserBanner, middleware.UserMiddleware)
+	router.GET("/banner", wrapper.GetBanner, auth.AdminMiddleware)
+	router.POST("/banner", wrapper.PostBanner, auth.AdminMiddleware)
+	router.DELETE("/banner", wrapper.DeleteBanner, auth.AdminMiddleware)
+	router.DELETE("/banner/:id", wrapper.DeleteBannerId, auth.AdminMiddleware)
+	router.PATCH("/banner/:id", wrapper.PatchBannerId, auth.AdminMiddleware)
+	router.GET("/banner/export", wrapper.GetBannerExport, auth.AdminMiddleware)
+	router.POST("/banner/import", wrapper.PostBannerImport, auth.AdminMiddleware)
+	router.POST("/banner/:id/restore", wrapper.PostBannerIdRestore, auth.AdminMiddleware)
+	router.GET("/banner/versions/:id", wrapper.GetBannerVersionsId, auth.AdminMiddleware)
+	router.PUT("/banner/versions/:id/activate", wrapper.PutBannerVersionsIdActivate, auth.AdminMiddleware)
+	router.GET("/audit", wrapper.GetAudit, auth.AdminMiddleware)
+	router.GET("/tokens", wrapper.GetTokens, auth.AdminMiddleware)
+	router.POST("/tokens", wrapper.PostTokens, auth.AdminMiddleware)
+	router.DELETE("/tokens/:id", wrapper.DeleteTokensId, auth.AdminMiddleware)
+	router.GET("/jobs/:id", wrapper.GetJobsId, auth.AdminMiddleware)
+	router.GET("/feature/:id/schema", wrapper.GetFeatureIdSchema, auth.AdminMiddleware)
+	router.PUT("/feature/:id/schema", wrapper.PutFeatureIdSchema, auth.AdminMiddleware)
+	router.DELETE("/feature/:id/schema", wrapper.DeleteFeatureIdSchema, auth.AdminMiddleware)
+	router.GET("/user_banner", wrapper.GetUserBanner, auth.UserMiddleware, limiter.Middleware)
+	router.POST("/user_banner/batch", wrapper.PostUserBannerBatch, auth.UserMiddleware, limiter.Middleware)
+}
+
+// RegisterHealthHandlers mounts the health and metrics endpoints. They are
+// meant for orchestrators and monitoring and do not require a token.
+func RegisterHealthHandlers(router generated.EchoRouter, s *Server) {
+	router.GET("/healthz", s.GetHealthz)
+	router.GET("/readyz", s.GetReadyz)
+	router.GET("/health/cache", s.GetCacheHealth)
+	router.GET("/metrics", echo.WrapHandler(s.Metrics.Handler()))
 }
@@ -0,0 +1,98 @@
+package server
+
+import (
+	"avito/internal/db"
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/labstack/echo/v4"
+)
+
+const readinessTimeout = 2 * time.Second
+
+const (
+	checkUp   = "up"
+	checkDown = "down"
+)
+
+type HealthResponse struct {
+	Status string `json:"status"`
+}
+
+type ReadinessCheck struct {
+	Status    string `json:"status"`
+	Required  bool   `json:"required"`
+	LatencyMs int64  `json:"latency_ms"`
+	Error     string `json:"error,omitempty"`
+}
+
+type ReadinessResponse struct {
+	Status string                    `json:"status"`
+	Checks map[string]ReadinessCheck `json:"checks"`
+}
+
+// GetHealthz only tells that the process is up and serving HTTP.
+func (s *Server) GetHealthz(ctx echo.Context) error {
+	return ctx.JSON(http.StatusOK, HealthResponse{Status: "ok"})
+}
+
+// GetReadyz checks the dependencies. Postgres and the schema are required,
+// a failure there yields 503. Redis is reported but optional: without it
+// banners are served from the database, so the status is only "degraded".
+func (s *Server) GetReadyz(ctx echo.Context) error {
+	c, cancel := context.WithTimeout(ctx.Request().Context(), readinessTimeout)
+	defer cancel()
+
+	checks := map[string]ReadinessCheck{
+		"postgres": runCheck(true, func() error {
+			sqlDB, err := s.DB.DB()
+			if err != nil {
+				return err
+			}
+			return sqlDB.PingContext(c)
+		}),
+		"migrations": runCheck(true, func() error {
+			return db.CheckMigrations(s.DB.WithContext(c))
+		}),
+		"redis": runCheck(false, func() error {
+			return s.Redis.Ping(c).Err()
+		}),
+	}
+
+	response := ReadinessResponse{Status: "ok", Checks: checks}
+	status := http.StatusOK
+	for _, check := range checks {
+		if check.Status == checkUp {
+			continue
+		}
+		if check.Required {
+			response.Status = "unavailable"
+			status = http.StatusServiceUnavailable
+		} else if response.Status == "ok" {
+			response.Status = "degraded"
+		}
+	}
+	return ctx.JSON(status, response)
+}
+
+func runCheck(required bool, check func() error) ReadinessCheck {
+	start := time.Now()
+	err := check()
+	result := ReadinessCheck{
+		Status:    checkUp,
+		Required:  required,
+		LatencyMs: time.Since(start).Milliseconds(),
+	}
+	if err != nil {
+		result.Status = checkDown
+		result.Error = err.Error()
+	}
+	return result
+}
+
+// GetCacheHealth reports the state of the Redis circuit breaker. An open
+// breaker is not an error: banners are still served from the database.
+func (s *Server) GetCacheHealth(ctx echo.Context) error {
+	return ctx.JSON(http.StatusOK, s.cacheBreaker.status())
+}
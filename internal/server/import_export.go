@@ -0,0 +1,419 @@
+package server
+
+import (
+	"avito/internal/db"
+	"avito/internal/generated"
+	"avito/internal/server/middleware"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const (
+	importModeFail   = "fail"
+	importModeSkip   = "skip"
+	importModeUpsert = "upsert"
+
+	importCreated  = "created"
+	importUpdated  = "updated"
+	importSkipped  = "skipped"
+	importConflict = "conflict"
+	importInvalid  = "invalid"
+)
+
+var exportContentTypes = map[string]string{
+	formatNDJSON: "application/x-ndjson",
+	formatCSV:    "text/csv; charset=utf-8",
+}
+
+// ImportReport describes what the import did with every line. When the
+// import is cancelled the lines before the failing one show what would have
+// been applied; nothing is committed.
+type ImportReport struct {
+	Mode    string             `json:"mode"`
+	Created int                `json:"created"`
+	Updated int                `json:"updated"`
+	Skipped int                `json:"skipped"`
+	Error   string             `json:"error,omitempty"`
+	Lines   []ImportLineResult `json:"lines"`
+}
+
+type ImportLineResult struct {
+	Line     int    `json:"line"`
+	Status   string `json:"status"`
+	BannerID uint   `json:"banner_id,omitempty"`
+	Error    string `json:"error,omitempty"`
+}
+
+// importLineError rejects a line and cancels the whole import.
+type importLineError struct {
+	code     int
+	status   string
+	bannerID uint
+	message  string
+}
+
+func (e *importLineError) Error() string {
+	return e.message
+}
+
+func invalidImportLine(message string) *importLineError {
+	return &importLineError{code: http.StatusBadRequest, status: importInvalid, message: message}
+}
+
+func conflictingImportLine(bannerID uint, message string) *importLineError {
+	return &importLineError{code: http.StatusConflict, status: importConflict, bannerID: bannerID, message: message}
+}
+
+// GetBannerExport streams all live banners in batches of jobBatchSize,
+// flushing after every batch. Errors after the first batch can only cut the
+// stream short, since the status has already been sent.
+func (s *Server) GetBannerExport(ctx echo.Context, params generated.GetBannerExportParams) error {
+	logger := middleware.Logger(ctx)
+
+	format := formatNDJSON
+	if params.Format != nil {
+		format = string(*params.Format)
+	}
+	contentType, ok := exportContentTypes[format]
+	if !ok {
+		logger.Warn("Unknown export format", "format", format)
+		return echo.NewHTTPError(http.StatusBadRequest, "format must be ndjson or csv")
+	}
+
+	response := ctx.Response()
+	response.Header().Set(echo.HeaderContentType, contentType)
+	response.Header().Set(echo.HeaderContentDisposition, fmt.Sprintf(`attachment; filename="banners.%s"`, format))
+	writer, err := newRecordWriter(format, response)
+	if err != nil {
+		logger.Error("Failed to start export", "error", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to start export: "+err.Error())
+	}
+
+	exported := 0
+	var afterID uint
+	for {
+		var banners []bannerWithTags
+		if err := s.bannersWithTagsQuery(ctx).Where("banners.id > ?", afterID).Limit(jobBatchSize).Scan(&banners).Error; err != nil {
+			logger.Error("Failed to fetch banners for export", "afterID", afterID, "error", err)
+			return echo.NewHTTPError(http.StatusInternalServerError, "Failed to fetch banners from database")
+		}
+
+		for _, banner := range banners {
+			if err := writer.Write(newBannerRecord(banner)); err != nil {
+				logger.Error("Failed to write exported banner", "bannerID", banner.ID, "error", err)
+				return echo.NewHTTPError(http.StatusInternalServerError, "Failed to write export")
+			}
+		}
+		if err := writer.Flush(); err != nil {
+			logger.Error("Failed to write export", "error", err)
+			return echo.NewHTTPError(http.StatusInternalServerError, "Failed to write export")
+		}
+		exported += len(banners)
+
+		if len(banners) < jobBatchSize {
+			break
+		}
+		response.Flush()
+		afterID = banners[len(banners)-1].ID
+	}
+
+	// An empty NDJSON export has not written anything yet.
+	if !response.Committed {
+		response.WriteHeader(http.StatusOK)
+	}
+	logger.Info("Banners exported", "format", format, "count", exported)
+	return nil
+}
+
+func newBannerRecord(banner bannerWithTags) bannerRecord {
+	featureID := banner.FeatureID
+	isActive := banner.IsActive
+	createdAt := banner.CreatedAt
+	updatedAt := banner.UpdatedAt
+	tagIDs := make([]int, len(banner.TagIDs))
+	for i, tagId := range banner.TagIDs {
+		tagIDs[i] = int(tagId)
+	}
+	return bannerRecord{
+		BannerID:    banner.ID,
+		FeatureID:   &featureID,
+		TagIDs:      &tagIDs,
+		IsActive:    &isActive,
+		ActiveFrom:  banner.ActiveFrom,
+		ActiveUntil: banner.ActiveUntil,
+		CreatedAt:   &createdAt,
+		UpdatedAt:   &updatedAt,
+		Content:     banner.Content,
+	}
+}
+
+// PostBannerImport applies an export in one transaction. A line conflicts
+// when one of its feature/tag pairs belongs to an existing banner; the mode
+// decides whether that cancels the import, skips the line or updates the
+// banner. The import stops at the first invalid or conflicting line and
+// answers with the report up to that line.
+func (s *Server) PostBannerImport(ctx echo.Context, params generated.PostBannerImportParams) error {
+	logger := middleware.Logger(ctx)
+
+	format := formatNDJSON
+	if params.Format != nil {
+		format = string(*params.Format)
+	}
+	if _, ok := exportContentTypes[format]; !ok {
+		logger.Warn("Unknown import format", "format", format)
+		return echo.NewHTTPError(http.StatusBadRequest, "format must be ndjson or csv")
+	}
+	mode := importModeFail
+	if params.Mode != nil {
+		mode = string(*params.Mode)
+	}
+	if mode != importModeFail && mode != importModeSkip && mode != importModeUpsert {
+		logger.Warn("Unknown import mode", "mode", mode)
+		return echo.NewHTTPError(http.StatusBadRequest, "mode must be fail, skip or upsert")
+	}
+
+	report := ImportReport{Mode: mode, Lines: []ImportLineResult{}}
+	reader, err := newRecordReader(format, ctx.Request().Body)
+	if err != nil {
+		logger.Warn("Invalid import header", "error", err)
+		report.Error = err.Error()
+		return ctx.JSON(http.StatusBadRequest, report)
+	}
+
+	tx := s.requestDB(ctx).Begin()
+	if tx.Error != nil {
+		logger.Error("Failed to start database transaction", "error", tx.Error)
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to start database transaction")
+	}
+
+	var touched []db.BannerFeatureTag
+	for {
+		record, line, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			tx.Rollback()
+			if errors.Is(err, errInvalidRecord) {
+				return cancelImport(ctx, report, line, invalidImportLine(err.Error()))
+			}
+			logger.Error("Failed to read import", "line", line, "error", err)
+			return echo.NewHTTPError(http.StatusBadRequest, "Failed to read request body: "+err.Error())
+		}
+
+		result, pairs, err := s.importRecord(tx, ctx, mode, record)
+		if err != nil {
+			tx.Rollback()
+			var lineErr *importLineError
+			if errors.As(err, &lineErr) {
+				return cancelImport(ctx, report, line, lineErr)
+			}
+			logger.Error("Failed to import banner", "line", line, "error", err)
+			return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to import line %d: %v", line, err))
+		}
+
+		result.Line = line
+		report.Lines = append(report.Lines, result)
+		switch result.Status {
+		case importCreated:
+			report.Created++
+		case importUpdated:
+			report.Updated++
+		case importSkipped:
+			report.Skipped++
+		}
+		touched = append(touched, pairs...)
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		logger.Error("Failed to commit transaction", "error", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to commit transaction: "+err.Error())
+	}
+
+	s.invalidateBannerCache(ctx.Request().Context(), touched)
+
+	logger.Info("Banners imported", "mode", mode, "created", report.Created, "updated", report.Updated, "skipped", report.Skipped)
+	return ctx.JSON(http.StatusOK, report)
+}
+
+func cancelImport(ctx echo.Context, report ImportReport, line int, lineErr *importLineError) error {
+	middleware.Logger(ctx).Warn("Import cancelled", "line", line, "status", lineErr.status, "error", lineErr.message)
+	report.Lines = append(report.Lines, ImportLineResult{
+		Line:     line,
+		Status:   lineErr.status,
+		BannerID: lineErr.bannerID,
+		Error:    lineErr.message,
+	})
+	report.Error = fmt.Sprintf("Import cancelled at line %d: %s", line, lineErr.message)
+	return ctx.JSON(lineErr.code, report)
+}
+
+// importRecord validates a record and applies it in tx. It returns the
+// feature/tag pairs whose cache entries have to be dropped.
+func (s *Server) importRecord(tx *gorm.DB, ctx echo.Context, mode string, record bannerRecord) (ImportLineResult, []db.BannerFeatureTag, error) {
+	logger := middleware.Logger(ctx)
+
+	if record.FeatureID == nil || record.TagIDs == nil || record.IsActive == nil || record.Content == nil {
+		return ImportLineResult{}, nil, invalidImportLine("Missing required fields: feature_id, tag_ids, is_active and content must be provided")
+	}
+	var content map[string]interface{}
+	if err := json.Unmarshal(record.Content, &content); err != nil || content == nil {
+		return ImportLineResult{}, nil, invalidImportLine("content must be a JSON object")
+	}
+	seen := make(map[int]bool, len(*record.TagIDs))
+	for _, tagId := range *record.TagIDs {
+		if seen[tagId] {
+			return ImportLineResult{}, nil, invalidImportLine(fmt.Sprintf("Duplicate tag id %d", tagId))
+		}
+		seen[tagId] = true
+	}
+
+	banner := db.Banner{
+		IsActive:    *record.IsActive,
+		ActiveFrom:  record.ActiveFrom,
+		ActiveUntil: record.ActiveUntil,
+		Content:     getJsonFromPointer(&content),
+	}
+	if err := importValidation(validateActiveWindow(logger, banner.ActiveFrom, banner.ActiveUntil)); err != nil {
+		return ImportLineResult{}, nil, err
+	}
+	if err := importValidation(validateBannerContent(logger, tx, *record.FeatureID, banner.Content)); err != nil {
+		return ImportLineResult{}, nil, err
+	}
+
+	var owners []uint
+	if len(*record.TagIDs) > 0 {
+		if err := tx.Model(&db.BannerFeatureTag{}).Distinct("banner_id").
+			Where("feature_id = ? AND tag_id IN ?", *record.FeatureID, *record.TagIDs).
+			Order("banner_id").Pluck("banner_id", &owners).Error; err != nil {
+			return ImportLineResult{}, nil, err
+		}
+	}
+
+	switch {
+	case len(owners) == 0:
+		if record.CreatedAt != nil {
+			banner.CreatedAt = *record.CreatedAt
+		}
+		if record.UpdatedAt != nil {
+			banner.UpdatedAt = *record.UpdatedAt
+		}
+		pairs, err := importCreate(tx, ctx, &banner, *record.FeatureID, *record.TagIDs)
+		if err != nil {
+			return ImportLineResult{}, nil, err
+		}
+		return ImportLineResult{Status: importCreated, BannerID: banner.ID}, pairs, nil
+	case mode == importModeSkip:
+		return ImportLineResult{Status: importSkipped, BannerID: owners[0]}, nil, nil
+	case mode == importModeUpsert && len(owners) == 1:
+		pairs, err := importUpdate(tx, ctx, owners[0], banner, *record.FeatureID, *record.TagIDs)
+		if err != nil {
+			return ImportLineResult{}, nil, err
+		}
+		return ImportLineResult{Status: importUpdated, BannerID: owners[0]}, pairs, nil
+	case mode == importModeUpsert:
+		return ImportLineResult{}, nil, conflictingImportLine(owners[0], fmt.Sprintf("Feature and tag combinations belong to %d banners", len(owners)))
+	default:
+		return ImportLineResult{}, nil, conflictingImportLine(owners[0], fmt.Sprintf("Feature and tag combination is taken by banner %d", owners[0]))
+	}
+}
+
+// importValidation turns a 400 from the shared validators into an invalid
+// line and passes other errors through.
+func importValidation(err error) error {
+	var httpErr *echo.HTTPError
+	if !errors.As(err, &httpErr) || httpErr.Code != http.StatusBadRequest {
+		return err
+	}
+	switch message := httpErr.Message.(type) {
+	case string:
+		return invalidImportLine(message)
+	case ContentValidationError:
+		return invalidImportLine(fmt.Sprintf("%s: %v", message.Message, message.Violations))
+	}
+	return invalidImportLine(fmt.Sprint(httpErr.Message))
+}
+
+// importCreate inserts the banner the same way PostBanner does. banner.ID is
+// set on return.
+func importCreate(tx *gorm.DB, ctx echo.Context, banner *db.Banner, featureId int, tagIds []int) ([]db.BannerFeatureTag, error) {
+	if err := tx.Create(banner).Error; err != nil {
+		return nil, err
+	}
+	pairs, err := importPairs(tx, banner.ID, featureId, tagIds)
+	if err != nil {
+		return nil, err
+	}
+	if err := snapshotBannerVersion(tx, *banner, featureId, tagIds); err != nil {
+		return nil, err
+	}
+	if err := recordAudit(tx, ctx, db.AuditBannerCreate, banner.ID, nil, newAuditSnapshot(*banner, featureId, tagIds)); err != nil {
+		return nil, err
+	}
+	return pairs, nil
+}
+
+// importUpdate overwrites the banner with the imported values and replaces
+// its pairs, like a PATCH with every field set.
+func importUpdate(tx *gorm.DB, ctx echo.Context, id uint, imported db.Banner, featureId int, tagIds []int) ([]db.BannerFeatureTag, error) {
+	var banner db.Banner
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&banner, id).Error; err != nil {
+		return nil, err
+	}
+	original := banner
+
+	var existingTags []db.BannerFeatureTag
+	if err := tx.Where("banner_id = ?", id).Find(&existingTags).Error; err != nil {
+		return nil, err
+	}
+
+	banner.IsActive = imported.IsActive
+	banner.ActiveFrom = imported.ActiveFrom
+	banner.ActiveUntil = imported.ActiveUntil
+	banner.Content = imported.Content
+	if err := tx.Save(&banner).Error; err != nil {
+		return nil, err
+	}
+	if err := tx.Where("banner_id = ?", id).Delete(&db.BannerFeatureTag{}).Error; err != nil {
+		return nil, err
+	}
+	pairs, err := importPairs(tx, id, featureId, tagIds)
+	if err != nil {
+		return nil, err
+	}
+	if err := snapshotBannerVersion(tx, banner, featureId, tagIds); err != nil {
+		return nil, err
+	}
+	before := pairsSnapshot(original, existingTags)
+	after := newAuditSnapshot(banner, featureId, tagIds)
+	if err := recordAudit(tx, ctx, db.AuditBannerUpdate, id, before, after); err != nil {
+		return nil, err
+	}
+	return append(existingTags, pairs...), nil
+}
+
+func importPairs(tx *gorm.DB, bannerID uint, featureId int, tagIds []int) ([]db.BannerFeatureTag, error) {
+	pairs := make([]db.BannerFeatureTag, 0, len(tagIds))
+	for _, tagId := range tagIds {
+		bftEntry := db.BannerFeatureTag{
+			BannerID:  bannerID,
+			FeatureID: featureId,
+			TagID:     tagId,
+		}
+		if err := tx.Create(&bftEntry).Error; err != nil {
+			if isDuplicateEntryError(err) {
+				return nil, conflictingImportLine(0, fmt.Sprintf("Duplicate feature and tag combination: feature %d, tag %d", featureId, tagId))
+			}
+			return nil, err
+		}
+		pairs = append(pairs, bftEntry)
+	}
+	return pairs, nil
+}
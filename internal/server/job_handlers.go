@@ -0,0 +1,82 @@
+package server
+
+import (
+	"avito/internal/db"
+	"avito/internal/generated"
+	"avito/internal/server/middleware"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"gorm.io/gorm"
+)
+
+type JobResponse struct {
+	ID        uint      `json:"job_id"`
+	Status    string    `json:"status"`
+	FeatureID *int      `json:"feature_id"`
+	TagID     *int      `json:"tag_id"`
+	Deleted   int       `json:"deleted"`
+	Error     string    `json:"error,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type JobResponseAccepted struct {
+	JobId uint `json:"job_id"`
+}
+
+func (s *Server) DeleteBanner(ctx echo.Context, params generated.DeleteBannerParams) error {
+	logger := middleware.Logger(ctx)
+
+	if params.FeatureId == nil && params.TagId == nil {
+		logger.Warn("Bulk delete requested without a filter")
+		return echo.NewHTTPError(http.StatusBadRequest, "At least one of feature_id and tag_id must be provided")
+	}
+
+	job := db.Job{
+		Kind:      jobKindDeleteBanners,
+		Status:    db.JobPending,
+		FeatureID: params.FeatureId,
+		TagID:     params.TagId,
+
+		ActorTokenID: middleware.TokenID(ctx),
+		ActorRole:    middleware.Role(ctx),
+		RequestID:    middleware.RequestID(ctx),
+	}
+	if err := s.requestDB(ctx).Create(&job).Error; err != nil {
+		logger.Error("Failed to queue bulk delete job", "error", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to queue job: "+err.Error())
+	}
+
+	s.wakeJobWorker()
+
+	logger.Info("Bulk delete job queued", "jobID", job.ID, "feature", params.FeatureId, "tag", params.TagId)
+	return ctx.JSON(http.StatusAccepted, JobResponseAccepted{JobId: job.ID})
+}
+
+func (s *Server) GetJobsId(ctx echo.Context, id int, params generated.GetJobsIdParams) error {
+	logger := middleware.Logger(ctx)
+
+	var job db.Job
+	if err := s.requestDB(ctx).First(&job, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			logger.Warn("Job not found", "jobID", id)
+			return echo.NewHTTPError(http.StatusNotFound, "Job not found")
+		}
+		logger.Error("Database error on retrieving job", "error", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "Database error: "+err.Error())
+	}
+
+	return ctx.JSON(http.StatusOK, JobResponse{
+		ID:        job.ID,
+		Status:    job.Status,
+		FeatureID: job.FeatureID,
+		TagID:     job.TagID,
+		Deleted:   job.Deleted,
+		Error:     job.Error,
+		CreatedAt: job.CreatedAt,
+		UpdatedAt: job.UpdatedAt,
+	})
+}
@@ -0,0 +1,231 @@
+package server
+
+import (
+	"avito/internal/db"
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const (
+	jobKindDeleteBanners = "delete_banners"
+
+	jobBatchSize    = 100
+	jobPollInterval = 5 * time.Second
+
+	// jobStaleTimeout is how long a running job may go without finishing a
+	// batch before another worker takes it over. Every batch bumps the job's
+	// updated_at, so only jobs whose worker died are reclaimed.
+	jobStaleTimeout = 5 * time.Minute
+)
+
+// errJobInterrupted stops a job between batches on shutdown. The job goes
+// back to pending and is resumed by the next worker that claims it.
+var errJobInterrupted = errors.New("job interrupted by shutdown")
+
+// runJobWorker processes queued jobs one at a time until the server stops.
+// Jobs are kept in the jobs table, so any replica may pick them up;
+// wakeJobWorker only saves the local worker from waiting for the next poll.
+func (s *Server) runJobWorker() {
+	ticker := time.NewTicker(jobPollInterval)
+	defer ticker.Stop()
+
+	for {
+		for !s.stopping() && s.processNextJob() {
+		}
+
+		select {
+		case <-s.stop:
+			return
+		case <-s.jobsWakeup:
+		case <-ticker.C:
+		}
+	}
+}
+
+func (s *Server) stopping() bool {
+	select {
+	case <-s.stop:
+		return true
+	default:
+		return false
+	}
+}
+
+func (s *Server) wakeJobWorker() {
+	select {
+	case s.jobsWakeup <- struct{}{}:
+	default:
+	}
+}
+
+// processNextJob runs the oldest pending job and reports whether there was
+// one.
+func (s *Server) processNextJob() bool {
+	job, err := s.claimJob()
+	if err != nil {
+		slog.Error("Failed to claim job", "error", err)
+		return false
+	}
+	if job == nil {
+		return false
+	}
+
+	slog.Info("Starting job", "jobID", job.ID, "kind", job.Kind)
+
+	var runErr error
+	switch job.Kind {
+	case jobKindDeleteBanners:
+		runErr = s.runDeleteBannersJob(job)
+	default:
+		runErr = fmt.Errorf("unknown job kind %q", job.Kind)
+	}
+
+	if errors.Is(runErr, errJobInterrupted) {
+		slog.Info("Job interrupted, returning it to the queue", "jobID", job.ID, "deleted", job.Deleted)
+		job.Status = db.JobPending
+	} else if runErr != nil {
+		slog.Error("Job failed", "jobID", job.ID, "error", runErr)
+		job.Status = db.JobFailed
+		job.Error = runErr.Error()
+	} else {
+		slog.Info("Job completed successfully", "jobID", job.ID, "deleted", job.Deleted)
+		job.Status = db.JobDone
+	}
+
+	if err := s.DB.Save(job).Error; err != nil {
+		slog.Error("Failed to save job status", "jobID", job.ID, "error", err)
+	}
+	return true
+}
+
+// claimJob marks the oldest pending job as running and returns it, or nil
+// when there is nothing to do. A running job that has made no progress for
+// jobStaleTimeout was left behind by a crashed worker and is claimed again;
+// the job resumes where it stopped, since deleted banners no longer match
+// its filter. SKIP LOCKED keeps replicas from claiming the same job.
+func (s *Server) claimJob() (*db.Job, error) {
+	var job db.Job
+	err := s.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("status = ? OR (status = ? AND updated_at < ?)", db.JobPending, db.JobRunning, time.Now().Add(-jobStaleTimeout)).
+			Order("id").First(&job).Error; err != nil {
+			return err
+		}
+		if job.Status == db.JobRunning {
+			slog.Warn("Reclaiming stale job", "jobID", job.ID, "updatedAt", job.UpdatedAt, "deleted", job.Deleted)
+		}
+		job.Status = db.JobRunning
+		return tx.Save(&job).Error
+	})
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &job, nil
+}
+
+// runDeleteBannersJob removes the banners matching the job filter in batches
+// of jobBatchSize, each batch in its own transaction, and drops the cache
+// entries of every pair the removed banners occupied.
+func (s *Server) runDeleteBannersJob(job *db.Job) error {
+	for {
+		if s.stopping() {
+			return errJobInterrupted
+		}
+
+		query := s.DB.Model(&db.BannerFeatureTag{}).Distinct("banner_id")
+		if job.FeatureID != nil {
+			query = query.Where("feature_id = ?", *job.FeatureID)
+		}
+		if job.TagID != nil {
+			query = query.Where("tag_id = ?", *job.TagID)
+		}
+
+		var bannerIDs []uint
+		if err := query.Order("banner_id").Limit(jobBatchSize).Pluck("banner_id", &bannerIDs).Error; err != nil {
+			return fmt.Errorf("failed to select banners: %v", err)
+		}
+		if len(bannerIDs) == 0 {
+			return nil
+		}
+
+		var deleted []uint
+		var pairs []db.BannerFeatureTag
+		err := s.DB.Transaction(func(tx *gorm.DB) error {
+			var err error
+			deleted, pairs, err = deleteBannerBatch(tx, job, bannerIDs)
+			if err != nil {
+				return err
+			}
+			job.Deleted += len(deleted)
+			return tx.Model(job).Update("deleted", job.Deleted).Error
+		})
+		if err != nil {
+			return fmt.Errorf("failed to delete batch: %v", err)
+		}
+
+		slog.Info("Deleted banner batch", "jobID", job.ID, "count", len(deleted))
+		s.invalidateBannerCache(context.Background(), pairs)
+	}
+}
+
+// deleteBannerBatch moves the candidate banners that still match the job
+// filter to the trash, auditing each on behalf of the job's actor, and
+// returns their ids and pairs. The candidates were
+// selected outside the transaction, so the banners are locked first and
+// their pairs read again: a banner changed in the meantime may no longer
+// match, or may hold pairs the selection did not see.
+func deleteBannerBatch(tx *gorm.DB, job *db.Job, candidateIDs []uint) ([]uint, []db.BannerFeatureTag, error) {
+	var banners []db.Banner
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id IN ?", candidateIDs).Order("id").Find(&banners).Error; err != nil {
+		return nil, nil, err
+	}
+	var candidatePairs []db.BannerFeatureTag
+	if err := tx.Where("banner_id IN ?", candidateIDs).Find(&candidatePairs).Error; err != nil {
+		return nil, nil, err
+	}
+
+	pairsByBanner := make(map[uint][]db.BannerFeatureTag, len(banners))
+	matching := make(map[uint]bool, len(banners))
+	for _, pair := range candidatePairs {
+		pairsByBanner[pair.BannerID] = append(pairsByBanner[pair.BannerID], pair)
+		if (job.FeatureID == nil || pair.FeatureID == *job.FeatureID) && (job.TagID == nil || pair.TagID == *job.TagID) {
+			matching[pair.BannerID] = true
+		}
+	}
+
+	var bannerIDs []uint
+	var pairs []db.BannerFeatureTag
+	for _, banner := range banners {
+		if !matching[banner.ID] {
+			continue
+		}
+		if err := snapshotBannerPairs(tx, banner, pairsByBanner[banner.ID]); err != nil {
+			return nil, nil, err
+		}
+		if err := recordJobAudit(tx, job, db.AuditBannerDelete, banner.ID, pairsSnapshot(banner, pairsByBanner[banner.ID]), nil); err != nil {
+			return nil, nil, err
+		}
+		bannerIDs = append(bannerIDs, banner.ID)
+		pairs = append(pairs, pairsByBanner[banner.ID]...)
+	}
+	if len(bannerIDs) == 0 {
+		return nil, nil, nil
+	}
+
+	if err := tx.Where("banner_id IN ?", bannerIDs).Delete(&db.BannerFeatureTag{}).Error; err != nil {
+		return nil, nil, err
+	}
+	if err := tx.Delete(&db.Banner{}, bannerIDs).Error; err != nil {
+		return nil, nil, err
+	}
+	return bannerIDs, pairs, nil
+}
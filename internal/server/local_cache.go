@@ -0,0 +1,120 @@
+package server
+
+import (
+	"container/list"
+	"sync"
+	"time"
+)
+
+// localCache is a size-bounded LRU of banner cache entries kept in process
+// memory in front of Redis. Entries expire after a TTL so that a replica
+// which missed an invalidation message serves stale data for a bounded time
+// only. A nil *localCache is a valid, always empty cache.
+type localCache struct {
+	size int
+	ttl  time.Duration
+
+	mu    sync.Mutex
+	items map[string]*list.Element
+	order *list.List
+
+	// generation is bumped by every removal. Loads started before a removal
+	// must not store their result, since it may predate the change.
+	generation uint64
+}
+
+type localCacheItem struct {
+	key       string
+	banner    *cachedBanner
+	expiresAt time.Time
+}
+
+// newLocalCache returns nil, which disables the cache, when size or ttl is
+// not positive.
+func newLocalCache(size int, ttl time.Duration) *localCache {
+	if size <= 0 || ttl <= 0 {
+		return nil
+	}
+	return &localCache{
+		size:  size,
+		ttl:   ttl,
+		items: make(map[string]*list.Element),
+		order: list.New(),
+	}
+}
+
+func (c *localCache) get(key string) *cachedBanner {
+	if c == nil {
+		return nil
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	element, ok := c.items[key]
+	if !ok {
+		return nil
+	}
+	item := element.Value.(*localCacheItem)
+	if !time.Now().Before(item.expiresAt) {
+		c.order.Remove(element)
+		delete(c.items, key)
+		return nil
+	}
+	c.order.MoveToFront(element)
+	return item.banner
+}
+
+// currentGeneration is taken before loading a value that will be passed to
+// set.
+func (c *localCache) currentGeneration() uint64 {
+	if c == nil {
+		return 0
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.generation
+}
+
+// set stores the banner unless something was removed since generation was
+// taken.
+func (c *localCache) set(key string, banner *cachedBanner, generation uint64) {
+	if c == nil {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if generation != c.generation {
+		return
+	}
+
+	item := &localCacheItem{key: key, banner: banner, expiresAt: time.Now().Add(c.ttl)}
+	if element, ok := c.items[key]; ok {
+		element.Value = item
+		c.order.MoveToFront(element)
+		return
+	}
+	c.items[key] = c.order.PushFront(item)
+	for c.order.Len() > c.size {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.items, oldest.Value.(*localCacheItem).key)
+	}
+}
+
+func (c *localCache) remove(keys ...string) {
+	if c == nil {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.generation++
+	for _, key := range keys {
+		if element, ok := c.items[key]; ok {
+			c.order.Remove(element)
+			delete(c.items, key)
+		}
+	}
+}
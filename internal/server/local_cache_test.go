@@ -0,0 +1,58 @@
+package server
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testBanner(title string) *cachedBanner {
+	return &cachedBanner{Content: json.RawMessage(`{"title":"` + title + `"}`), IsActive: true}
+}
+
+func TestLocalCacheEvictsLeastRecentlyUsed(t *testing.T) {
+	cache := newLocalCache(2, time.Minute)
+	cache.set("a", testBanner("a"), cache.currentGeneration())
+	cache.set("b", testBanner("b"), cache.currentGeneration())
+
+	require.NotNil(t, cache.get("a"))
+	cache.set("c", testBanner("c"), cache.currentGeneration())
+
+	assert.NotNil(t, cache.get("a"))
+	assert.Nil(t, cache.get("b"), "Least recently used entry should be evicted")
+	assert.NotNil(t, cache.get("c"))
+}
+
+func TestLocalCacheExpiresEntries(t *testing.T) {
+	cache := newLocalCache(10, 10*time.Millisecond)
+	cache.set("a", testBanner("a"), cache.currentGeneration())
+	require.NotNil(t, cache.get("a"))
+
+	time.Sleep(20 * time.Millisecond)
+	assert.Nil(t, cache.get("a"), "Entry should expire after the TTL")
+}
+
+func TestLocalCacheSkipsLoadsOlderThanRemoval(t *testing.T) {
+	cache := newLocalCache(10, time.Minute)
+	cache.set("a", testBanner("old"), cache.currentGeneration())
+
+	generation := cache.currentGeneration()
+	cache.remove("a")
+	cache.set("a", testBanner("stale"), generation)
+	assert.Nil(t, cache.get("a"), "A load started before the removal must not be stored")
+
+	cache.set("a", testBanner("new"), cache.currentGeneration())
+	assert.JSONEq(t, `{"title":"new"}`, string(cache.get("a").Content))
+}
+
+func TestDisabledLocalCache(t *testing.T) {
+	cache := newLocalCache(0, time.Minute)
+	require.Nil(t, cache)
+
+	cache.set("a", testBanner("a"), cache.currentGeneration())
+	cache.remove("a")
+	assert.Nil(t, cache.get("a"))
+}
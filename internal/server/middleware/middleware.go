@@ -1,57 +1,128 @@
 package middleware
 
 import (
+	"avito/internal/auth"
 	"net/http"
+	"strings"
 
 	"github.com/labstack/echo/v4"
 )
 
-func AdminMiddleware(next echo.HandlerFunc) echo.HandlerFunc {
-	return func(c echo.Context) error {
-		token := c.Request().Header.Get("token")
+const (
+	RoleAdmin = auth.RoleAdmin
+	RoleUser  = auth.RoleUser
 
-		if isValidUserToken(token) {
-			return echo.NewHTTPError(http.StatusForbidden, "No access")
+	roleContextKey    = "role"
+	tokenIDContextKey = "token_id"
+	tagIDContextKey   = "tag_id"
+
+	bearerPrefix = "Bearer "
+)
+
+// Auth authenticates requests either by an "Authorization: Bearer <jwt>"
+// header or by the legacy "token" header.
+type Auth struct {
+	tokens auth.TokenStore
+	jwt    *auth.JWTVerifier
+}
+
+// NewAuth returns an Auth that accepts legacy "token" headers when tokens is
+// not nil and bearer JWTs when verifier is not nil.
+func NewAuth(tokens auth.TokenStore, verifier *auth.JWTVerifier) *Auth {
+	return &Auth{tokens: tokens, jwt: verifier}
+}
+
+func (a *Auth) AdminMiddleware(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		token, err := a.authenticate(c)
+		if err != nil {
+			return err
 		}
 
-		if !isValidAdminToken(token) {
-			return echo.NewHTTPError(http.StatusUnauthorized, "Unauthorized")
+		if token.Role != RoleAdmin {
+			return echo.NewHTTPError(http.StatusForbidden, "No access")
 		}
 
+		setToken(c, token)
 		return next(c)
 	}
 }
 
-func UserMiddleware(next echo.HandlerFunc) echo.HandlerFunc {
+func (a *Auth) UserMiddleware(next echo.HandlerFunc) echo.HandlerFunc {
 	return func(c echo.Context) error {
-		token := c.Request().Header.Get("token")
-
-		if !isValidUserToken(token) && !isValidAdminToken(token) {
-			return echo.NewHTTPError(http.StatusUnauthorized, "Unauthorized")
+		token, err := a.authenticate(c)
+		if err != nil {
+			return err
 		}
 
+		setToken(c, token)
 		return next(c)
 	}
 }
 
-var validAdminTokens = []string{"admin1", "admin2", "admin3"}
+func (a *Auth) authenticate(c echo.Context) (*auth.Token, error) {
+	if header := c.Request().Header.Get(echo.HeaderAuthorization); strings.HasPrefix(header, bearerPrefix) {
+		return a.authenticateBearer(c, strings.TrimPrefix(header, bearerPrefix))
+	}
 
-var validUserTokens = []string{"user1", "user2", "user3"}
+	raw := c.Request().Header.Get("token")
+	if raw == "" || a.tokens == nil {
+		return nil, echo.NewHTTPError(http.StatusUnauthorized, "Unauthorized")
+	}
 
-func isValidAdminToken(token string) bool {
-	for _, t := range validAdminTokens {
-		if t == token {
-			return true
+	token, err := a.tokens.Lookup(c.Request().Context(), raw)
+	if err != nil {
+		if auth.IsTokenError(err) {
+			return nil, echo.NewHTTPError(http.StatusUnauthorized, "Unauthorized")
 		}
+		Logger(c).Error("Failed to look up token", "error", err)
+		return nil, echo.NewHTTPError(http.StatusInternalServerError, "Failed to verify token")
 	}
-	return false
+	return token, nil
 }
 
-func isValidUserToken(token string) bool {
-	for _, t := range validUserTokens {
-		if t == token {
-			return true
-		}
+func (a *Auth) authenticateBearer(c echo.Context, raw string) (*auth.Token, error) {
+	if a.jwt == nil {
+		return nil, echo.NewHTTPError(http.StatusUnauthorized, "Unauthorized")
+	}
+
+	token, err := a.jwt.Verify(raw)
+	if err != nil {
+		Logger(c).Warn("Rejected bearer token", "error", err)
+		return nil, echo.NewHTTPError(http.StatusUnauthorized, "Unauthorized")
 	}
-	return false
+	return token, nil
+}
+
+func setToken(c echo.Context, token *auth.Token) {
+	c.Set(roleContextKey, token.Role)
+	c.Set(tokenIDContextKey, token.ID)
+	if token.TagID != nil {
+		c.Set(tagIDContextKey, *token.TagID)
+	}
+}
+
+// Role returns the role assigned to the request by one of the auth
+// middlewares, or an empty string for unauthenticated routes.
+func Role(c echo.Context) string {
+	role, _ := c.Get(roleContextKey).(string)
+	return role
+}
+
+// TokenID returns the identifier of the token the request was authenticated
+// with.
+func TokenID(c echo.Context) string {
+	id, _ := c.Get(tokenIDContextKey).(string)
+	return id
+}
+
+// PinnedTagID returns the only tag the caller may query, if its credential
+// restricts it to one.
+func PinnedTagID(c echo.Context) (int, bool) {
+	tagID, ok := c.Get(tagIDContextKey).(int)
+	return tagID, ok
+}
+
+func IsAdmin(c echo.Context) bool {
+	return Role(c) == RoleAdmin
 }
@@ -0,0 +1,157 @@
+package middleware
+
+import (
+	"avito/internal/auth"
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var hmacSecret = []byte("test-secret")
+
+type fakeTokenStore map[string]auth.Token
+
+func (f fakeTokenStore) Lookup(_ context.Context, token string) (*auth.Token, error) {
+	t, ok := f[token]
+	if !ok {
+		return nil, auth.ErrTokenNotFound
+	}
+	return &t, nil
+}
+
+func newTestRouter(a *Auth) *echo.Echo {
+	e := echo.New()
+	handler := func(c echo.Context) error {
+		tagID, pinned := PinnedTagID(c)
+		return c.JSON(http.StatusOK, map[string]interface{}{
+			"role":     Role(c),
+			"token_id": TokenID(c),
+			"tag_id":   tagID,
+			"pinned":   pinned,
+		})
+	}
+	e.GET("/admin", handler, a.AdminMiddleware)
+	e.GET("/user", handler, a.UserMiddleware)
+	return e
+}
+
+func newRSAKey(t *testing.T) (*rsa.PrivateKey, *rsa.PublicKey) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	der, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	require.NoError(t, err)
+	public, err := auth.ParseRSAPublicKey(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}))
+	require.NoError(t, err)
+	return key, public
+}
+
+func sign(t *testing.T, method jwt.SigningMethod, key interface{}, claims auth.Claims) string {
+	signed, err := jwt.NewWithClaims(method, claims).SignedString(key)
+	require.NoError(t, err)
+	return signed
+}
+
+func claims(role string, expiresIn time.Duration) auth.Claims {
+	return auth.Claims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			Subject:   "tester",
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(expiresIn)),
+		},
+		Role: role,
+	}
+}
+
+func do(e *echo.Echo, path string, headers map[string]string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, path, nil)
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+	return rec
+}
+
+func bearer(token string) map[string]string {
+	return map[string]string{echo.HeaderAuthorization: "Bearer " + token}
+}
+
+func TestBearerAuthentication(t *testing.T) {
+	private, public := newRSAKey(t)
+	verifier, err := auth.NewJWTVerifier(hmacSecret, public)
+	require.NoError(t, err)
+	e := newTestRouter(NewAuth(nil, verifier))
+
+	pinned := claims(RoleUser, time.Hour)
+	pinned.TagID = new(int)
+	*pinned.TagID = 7
+
+	noExpiry := claims(RoleUser, time.Hour)
+	noExpiry.ExpiresAt = nil
+	noSubject := claims(RoleUser, time.Hour)
+	noSubject.Subject = ""
+
+	otherKey, _ := newRSAKey(t)
+
+	tests := []struct {
+		name   string
+		path   string
+		token  string
+		status int
+	}{
+		{"hs256 admin on admin route", "/admin", sign(t, jwt.SigningMethodHS256, hmacSecret, claims(RoleAdmin, time.Hour)), http.StatusOK},
+		{"rs256 admin on admin route", "/admin", sign(t, jwt.SigningMethodRS256, private, claims(RoleAdmin, time.Hour)), http.StatusOK},
+		{"user on admin route", "/admin", sign(t, jwt.SigningMethodRS256, private, claims(RoleUser, time.Hour)), http.StatusForbidden},
+		{"user on user route", "/user", sign(t, jwt.SigningMethodHS256, hmacSecret, pinned), http.StatusOK},
+		{"expired token", "/user", sign(t, jwt.SigningMethodHS256, hmacSecret, claims(RoleUser, -time.Minute)), http.StatusUnauthorized},
+		{"token without expiry", "/user", sign(t, jwt.SigningMethodHS256, hmacSecret, noExpiry), http.StatusUnauthorized},
+		{"token without subject", "/user", sign(t, jwt.SigningMethodHS256, hmacSecret, noSubject), http.StatusUnauthorized},
+		{"wrong hmac secret", "/user", sign(t, jwt.SigningMethodHS256, []byte("other"), claims(RoleUser, time.Hour)), http.StatusUnauthorized},
+		{"wrong rsa key", "/user", sign(t, jwt.SigningMethodRS256, otherKey, claims(RoleUser, time.Hour)), http.StatusUnauthorized},
+		{"unknown role", "/user", sign(t, jwt.SigningMethodHS256, hmacSecret, claims("guest", time.Hour)), http.StatusUnauthorized},
+		{"unsigned token", "/user", sign(t, jwt.SigningMethodNone, jwt.UnsafeAllowNoneSignatureType, claims(RoleAdmin, time.Hour)), http.StatusUnauthorized},
+		{"garbage", "/user", "not-a-jwt", http.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := do(e, tt.path, bearer(tt.token))
+			assert.Equal(t, tt.status, rec.Code, rec.Body.String())
+		})
+	}
+
+	rec := do(e, "/user", bearer(sign(t, jwt.SigningMethodHS256, hmacSecret, pinned)))
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, `{"role":"user","token_id":"jwt:tester","tag_id":7,"pinned":true}`, rec.Body.String())
+}
+
+func TestLegacyTokenSwitch(t *testing.T) {
+	verifier, err := auth.NewJWTVerifier(hmacSecret, nil)
+	require.NoError(t, err)
+	store := fakeTokenStore{
+		"admin1": {ID: "admin1", Role: RoleAdmin},
+		"user1":  {ID: "user1", Role: RoleUser},
+	}
+
+	enabled := newTestRouter(NewAuth(store, verifier))
+	assert.Equal(t, http.StatusOK, do(enabled, "/admin", map[string]string{"token": "admin1"}).Code)
+	assert.Equal(t, http.StatusForbidden, do(enabled, "/admin", map[string]string{"token": "user1"}).Code)
+	assert.Equal(t, http.StatusOK, do(enabled, "/user", map[string]string{"token": "user1"}).Code)
+	assert.Equal(t, http.StatusUnauthorized, do(enabled, "/user", map[string]string{"token": "nobody"}).Code)
+	assert.Equal(t, http.StatusUnauthorized, do(enabled, "/user", nil).Code)
+
+	disabled := newTestRouter(NewAuth(nil, verifier))
+	assert.Equal(t, http.StatusUnauthorized, do(disabled, "/admin", map[string]string{"token": "admin1"}).Code)
+	assert.Equal(t, http.StatusOK, do(disabled, "/admin", bearer(sign(t, jwt.SigningMethodHS256, hmacSecret, claims(RoleAdmin, time.Hour)))).Code)
+}
@@ -0,0 +1,190 @@
+package middleware
+
+import (
+	"avito/internal/config"
+	"context"
+	"math"
+	"net/http"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/labstack/echo/v4"
+)
+
+const (
+	rateLimitKeyPrefix = "ratelimit:"
+
+	// memoryBucketPruneInterval is how often the in-memory store forgets
+	// buckets that have refilled completely and so equal a fresh one.
+	memoryBucketPruneInterval = time.Minute
+)
+
+// RateLimitResult is the state of a bucket after taking a token from it.
+type RateLimitResult struct {
+	Allowed   bool
+	Remaining int
+	// RetryAfter is how long a rejected client has to wait for the next
+	// token.
+	RetryAfter time.Duration
+	// Reset is how long the bucket needs to fill up again.
+	Reset time.Duration
+}
+
+// RateLimitStore keeps token buckets. Take removes one token from the
+// bucket at key, creating a full bucket when there is none.
+type RateLimitStore interface {
+	Take(ctx context.Context, key string, limit config.RateLimit) (RateLimitResult, error)
+}
+
+// RateLimiter rejects requests with 429 once the token bucket of the caller
+// is empty. Buckets are keyed by token id, and by client address when
+// configured, and sized by the role of the token. It must run after one of
+// the auth middlewares.
+type RateLimiter struct {
+	store    RateLimitStore
+	fallback *MemoryRateLimitStore
+	limits   map[string]config.RateLimit
+	byIP     bool
+
+	// degraded is set while store fails, so the switch to memory and back
+	// is logged once rather than on every request.
+	degraded atomic.Bool
+}
+
+// NewRateLimiter returns a limiter that keeps buckets in store and falls
+// back to process memory while store fails. A nil store always uses memory.
+func NewRateLimiter(store RateLimitStore, cfg config.RateLimitConfig) *RateLimiter {
+	fallback := NewMemoryRateLimitStore()
+	if store == nil {
+		store = fallback
+	}
+	return &RateLimiter{
+		store:    store,
+		fallback: fallback,
+		limits: map[string]config.RateLimit{
+			RoleUser:  cfg.User,
+			RoleAdmin: cfg.Admin,
+		},
+		byIP: cfg.ByIP,
+	}
+}
+
+func (l *RateLimiter) Middleware(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		limit, ok := l.limits[Role(c)]
+		if !ok || limit.Rate <= 0 {
+			return next(c)
+		}
+
+		key := rateLimitKeyPrefix + TokenID(c)
+		if l.byIP {
+			key += ":" + c.RealIP()
+		}
+
+		ctx := c.Request().Context()
+		result, err := l.store.Take(ctx, key, limit)
+		if err != nil {
+			if l.degraded.CompareAndSwap(false, true) {
+				Logger(c).Warn("Rate limit store unavailable, using in-memory buckets", "error", err)
+			}
+			if result, err = l.fallback.Take(ctx, key, limit); err != nil {
+				return err
+			}
+		} else if l.degraded.CompareAndSwap(true, false) {
+			Logger(c).Info("Rate limit store recovered, using shared buckets")
+		}
+
+		header := c.Response().Header()
+		header.Set("X-RateLimit-Limit", strconv.Itoa(limit.Burst))
+		header.Set("X-RateLimit-Remaining", strconv.Itoa(result.Remaining))
+		header.Set("X-RateLimit-Reset", strconv.Itoa(ceilSeconds(result.Reset)))
+		if !result.Allowed {
+			header.Set("Retry-After", strconv.Itoa(ceilSeconds(result.RetryAfter)))
+			return echo.NewHTTPError(http.StatusTooManyRequests, "Too many requests")
+		}
+		return next(c)
+	}
+}
+
+func ceilSeconds(d time.Duration) int {
+	return int(math.Ceil(d.Seconds()))
+}
+
+type memoryBucket struct {
+	tokens  float64
+	updated time.Time
+	full    time.Time
+}
+
+// MemoryRateLimitStore keeps buckets in process memory, so every replica
+// enforces the limit on its own.
+type MemoryRateLimitStore struct {
+	now func() time.Time
+
+	mu      sync.Mutex
+	buckets map[string]*memoryBucket
+	pruned  time.Time
+}
+
+func NewMemoryRateLimitStore() *MemoryRateLimitStore {
+	return &MemoryRateLimitStore{
+		now:     time.Now,
+		buckets: make(map[string]*memoryBucket),
+	}
+}
+
+func (m *MemoryRateLimitStore) Take(_ context.Context, key string, limit config.RateLimit) (RateLimitResult, error) {
+	now := m.now()
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.prune(now)
+
+	bucket, ok := m.buckets[key]
+	if !ok {
+		bucket = &memoryBucket{tokens: float64(limit.Burst), updated: now}
+		m.buckets[key] = bucket
+	}
+
+	tokens, result := takeToken(bucket.tokens, now.Sub(bucket.updated), limit)
+	bucket.tokens = tokens
+	bucket.updated = now
+	bucket.full = now.Add(result.Reset)
+	return result, nil
+}
+
+func (m *MemoryRateLimitStore) prune(now time.Time) {
+	if now.Sub(m.pruned) < memoryBucketPruneInterval {
+		return
+	}
+	m.pruned = now
+	for key, bucket := range m.buckets {
+		if !now.Before(bucket.full) {
+			delete(m.buckets, key)
+		}
+	}
+}
+
+// takeToken refills a bucket holding tokens for elapsed and takes one token
+// from it. It returns the tokens left and the result for the caller.
+func takeToken(tokens float64, elapsed time.Duration, limit config.RateLimit) (float64, RateLimitResult) {
+	burst := float64(limit.Burst)
+	tokens = math.Min(burst, tokens+elapsed.Seconds()*limit.Rate)
+
+	var result RateLimitResult
+	if tokens >= 1 {
+		tokens--
+		result.Allowed = true
+	} else {
+		result.RetryAfter = secondsDuration((1 - tokens) / limit.Rate)
+	}
+	result.Remaining = int(tokens)
+	result.Reset = secondsDuration((burst - tokens) / limit.Rate)
+	return tokens, result
+}
+
+func secondsDuration(seconds float64) time.Duration {
+	return time.Duration(seconds * float64(time.Second))
+}
@@ -0,0 +1,152 @@
+package middleware
+
+import (
+	"avito/internal/config"
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type failingRateLimitStore struct{}
+
+func (failingRateLimitStore) Take(context.Context, string, config.RateLimit) (RateLimitResult, error) {
+	return RateLimitResult{}, errors.New("connection refused")
+}
+
+// toggledRateLimitStore fails while failing is set and uses memory otherwise.
+type toggledRateLimitStore struct {
+	failing atomic.Bool
+	memory  *MemoryRateLimitStore
+}
+
+func (s *toggledRateLimitStore) Take(ctx context.Context, key string, limit config.RateLimit) (RateLimitResult, error) {
+	if s.failing.Load() {
+		return RateLimitResult{}, errors.New("connection refused")
+	}
+	return s.memory.Take(ctx, key, limit)
+}
+
+func newRateLimitRouter(store RateLimitStore, cfg config.RateLimitConfig) *echo.Echo {
+	a := NewAuth(fakeTokenStore{
+		"user-1":  {ID: "u1", Role: RoleUser},
+		"user-2":  {ID: "u2", Role: RoleUser},
+		"admin-1": {ID: "a1", Role: RoleAdmin},
+	}, nil)
+	limiter := NewRateLimiter(store, cfg)
+
+	e := echo.New()
+	e.GET("/user_banner", func(c echo.Context) error {
+		return c.NoContent(http.StatusOK)
+	}, a.UserMiddleware, limiter.Middleware)
+	return e
+}
+
+func getWithToken(e *echo.Echo, token, ip string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, "/user_banner", nil)
+	req.Header.Set("token", token)
+	req.Header.Set(echo.HeaderXRealIP, ip)
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestRateLimiterRejectsEmptyBucket(t *testing.T) {
+	e := newRateLimitRouter(nil, config.RateLimitConfig{User: config.RateLimit{Rate: 1, Burst: 2}})
+
+	for i := 0; i < 2; i++ {
+		rec := getWithToken(e, "user-1", "10.0.0.1")
+		require.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, "2", rec.Header().Get("X-RateLimit-Limit"))
+	}
+
+	rec := getWithToken(e, "user-1", "10.0.0.1")
+	assert.Equal(t, http.StatusTooManyRequests, rec.Code)
+	assert.Equal(t, "0", rec.Header().Get("X-RateLimit-Remaining"))
+	assert.Equal(t, "1", rec.Header().Get("Retry-After"))
+	assert.Equal(t, "2", rec.Header().Get("X-RateLimit-Reset"))
+
+	// Another token has a bucket of its own, and admins are not limited.
+	assert.Equal(t, http.StatusOK, getWithToken(e, "user-2", "10.0.0.1").Code)
+	assert.Equal(t, http.StatusOK, getWithToken(e, "admin-1", "10.0.0.1").Code)
+	assert.Empty(t, getWithToken(e, "admin-1", "10.0.0.1").Header().Get("X-RateLimit-Limit"))
+}
+
+func TestRateLimiterKeysByClientAddress(t *testing.T) {
+	e := newRateLimitRouter(nil, config.RateLimitConfig{ByIP: true, User: config.RateLimit{Rate: 1, Burst: 1}})
+
+	assert.Equal(t, http.StatusOK, getWithToken(e, "user-1", "10.0.0.1").Code)
+	assert.Equal(t, http.StatusTooManyRequests, getWithToken(e, "user-1", "10.0.0.1").Code)
+	assert.Equal(t, http.StatusOK, getWithToken(e, "user-1", "10.0.0.2").Code)
+}
+
+func TestRateLimiterFallsBackToMemory(t *testing.T) {
+	e := newRateLimitRouter(failingRateLimitStore{}, config.RateLimitConfig{User: config.RateLimit{Rate: 1, Burst: 1}})
+
+	assert.Equal(t, http.StatusOK, getWithToken(e, "user-1", "10.0.0.1").Code)
+	assert.Equal(t, http.StatusTooManyRequests, getWithToken(e, "user-1", "10.0.0.1").Code)
+}
+
+func TestRateLimiterLogsFallbackSwitchesOnce(t *testing.T) {
+	var handlerLog bytes.Buffer
+	store := &toggledRateLimitStore{memory: NewMemoryRateLimitStore()}
+	a := NewAuth(fakeTokenStore{"user-1": {ID: "u1", Role: RoleUser}}, nil)
+	limiter := NewRateLimiter(store, config.RateLimitConfig{User: config.RateLimit{Rate: 100, Burst: 100}})
+
+	e := echo.New()
+	e.Use(RequestLogger(slog.New(slog.NewJSONHandler(&handlerLog, nil)), slog.New(slog.NewJSONHandler(io.Discard, nil))))
+	e.GET("/user_banner", func(c echo.Context) error {
+		return c.NoContent(http.StatusOK)
+	}, a.UserMiddleware, limiter.Middleware)
+
+	store.failing.Store(true)
+	for i := 0; i < 3; i++ {
+		require.Equal(t, http.StatusOK, getWithToken(e, "user-1", "10.0.0.1").Code)
+	}
+	store.failing.Store(false)
+	for i := 0; i < 3; i++ {
+		require.Equal(t, http.StatusOK, getWithToken(e, "user-1", "10.0.0.1").Code)
+	}
+
+	assert.Equal(t, 1, strings.Count(handlerLog.String(), "Rate limit store unavailable"))
+	assert.Equal(t, 1, strings.Count(handlerLog.String(), "Rate limit store recovered"))
+}
+
+func TestMemoryRateLimitStoreRefills(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	store := NewMemoryRateLimitStore()
+	store.now = func() time.Time { return now }
+	limit := config.RateLimit{Rate: 2, Burst: 2}
+
+	for i := 0; i < 2; i++ {
+		result, err := store.Take(context.Background(), "k", limit)
+		require.NoError(t, err)
+		require.True(t, result.Allowed)
+	}
+	result, err := store.Take(context.Background(), "k", limit)
+	require.NoError(t, err)
+	assert.False(t, result.Allowed)
+	assert.Equal(t, 500*time.Millisecond, result.RetryAfter)
+
+	now = now.Add(500 * time.Millisecond)
+	result, err = store.Take(context.Background(), "k", limit)
+	require.NoError(t, err)
+	assert.True(t, result.Allowed)
+	assert.Equal(t, 0, result.Remaining)
+
+	now = now.Add(2 * memoryBucketPruneInterval)
+	_, err = store.Take(context.Background(), "other", limit)
+	require.NoError(t, err)
+	assert.Len(t, store.buckets, 1)
+}
@@ -0,0 +1,112 @@
+package middleware
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/labstack/echo/v4"
+)
+
+const (
+	loggerContextKey       = "logger"
+	cacheOutcomeContextKey = "cache_outcome"
+
+	maxRequestIDLength = 128
+)
+
+// RequestLogger takes the X-Request-ID of the incoming request, or generates
+// one, and echoes it in the response. Handlers get a logger tagged with the
+// id through Logger. Once the request is done a single access log line is
+// written to accessLog.
+func RequestLogger(logger, accessLog *slog.Logger) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			start := time.Now()
+
+			id := c.Request().Header.Get(echo.HeaderXRequestID)
+			if !validRequestID(id) {
+				id = newRequestID()
+			}
+			c.Response().Header().Set(echo.HeaderXRequestID, id)
+			c.Set(loggerContextKey, logger.With("request_id", id))
+
+			err := next(c)
+
+			status := c.Response().Status
+			if err != nil {
+				var httpErr *echo.HTTPError
+				if errors.As(err, &httpErr) {
+					status = httpErr.Code
+				} else {
+					status = http.StatusInternalServerError
+				}
+			}
+
+			route := c.Path()
+			if route == "" {
+				route = "unmatched"
+			}
+			attrs := []slog.Attr{
+				slog.String("request_id", id),
+				slog.String("method", c.Request().Method),
+				slog.String("route", route),
+				slog.Int("status", status),
+				slog.Float64("latency_ms", float64(time.Since(start).Microseconds())/1000),
+			}
+			if role := Role(c); role != "" {
+				attrs = append(attrs, slog.String("role", role))
+			}
+			if outcome, ok := c.Get(cacheOutcomeContextKey).(string); ok {
+				attrs = append(attrs, slog.String("cache", outcome))
+			}
+			accessLog.LogAttrs(c.Request().Context(), slog.LevelInfo, "request", attrs...)
+			return err
+		}
+	}
+}
+
+// Logger returns the request-scoped logger, or the default logger outside
+// of RequestLogger.
+func Logger(c echo.Context) *slog.Logger {
+	if logger, ok := c.Get(loggerContextKey).(*slog.Logger); ok {
+		return logger
+	}
+	return slog.Default()
+}
+
+// RequestID returns the id assigned to the request by RequestLogger.
+func RequestID(c echo.Context) string {
+	return c.Response().Header().Get(echo.HeaderXRequestID)
+}
+
+// SetCacheOutcome records how the banner cache answered the request, for the
+// access log.
+func SetCacheOutcome(c echo.Context, outcome string) {
+	c.Set(cacheOutcomeContextKey, outcome)
+}
+
+// validRequestID accepts ids of printable ASCII characters only, so a client
+// cannot inject arbitrary text into the logs.
+func validRequestID(id string) bool {
+	if id == "" || len(id) > maxRequestIDLength {
+		return false
+	}
+	for i := 0; i < len(id); i++ {
+		if id[i] < '!' || id[i] > '~' {
+			return false
+		}
+	}
+	return true
+}
+
+func newRequestID() string {
+	var id [16]byte
+	if _, err := rand.Read(id[:]); err != nil {
+		return ""
+	}
+	return hex.EncodeToString(id[:])
+}
@@ -0,0 +1,85 @@
+package middleware
+
+import (
+	"avito/internal/auth"
+	"bytes"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRequestLogger(t *testing.T) {
+	var handlerLog, accessLog bytes.Buffer
+	store := fakeTokenStore{"user1": {ID: "user1", Role: RoleUser}}
+	a := NewAuth(store, nil)
+
+	e := echo.New()
+	e.Use(RequestLogger(slog.New(slog.NewJSONHandler(&handlerLog, nil)), slog.New(slog.NewJSONHandler(&accessLog, nil))))
+	e.GET("/banner/:id", func(c echo.Context) error {
+		Logger(c).Info("handling")
+		SetCacheOutcome(c, "redis_hit")
+		return echo.NewHTTPError(http.StatusNotFound, "Banner not found")
+	}, a.UserMiddleware)
+
+	rec := do(e, "/banner/5", map[string]string{"token": "user1", echo.HeaderXRequestID: "req-42"})
+	require.Equal(t, http.StatusNotFound, rec.Code)
+	assert.Equal(t, "req-42", rec.Header().Get(echo.HeaderXRequestID))
+
+	var handlerLine map[string]interface{}
+	require.NoError(t, json.Unmarshal(handlerLog.Bytes(), &handlerLine))
+	assert.Equal(t, "req-42", handlerLine["request_id"])
+
+	var line map[string]interface{}
+	require.NoError(t, json.Unmarshal(accessLog.Bytes(), &line))
+	assert.Equal(t, "req-42", line["request_id"])
+	assert.Equal(t, http.MethodGet, line["method"])
+	assert.Equal(t, "/banner/:id", line["route"])
+	assert.Equal(t, float64(http.StatusNotFound), line["status"])
+	assert.Equal(t, RoleUser, line["role"])
+	assert.Equal(t, "redis_hit", line["cache"])
+	assert.Contains(t, line, "latency_ms")
+}
+
+func TestAuthLogsThroughRequestLogger(t *testing.T) {
+	var handlerLog bytes.Buffer
+	verifier, err := auth.NewJWTVerifier(hmacSecret, nil)
+	require.NoError(t, err)
+	a := NewAuth(nil, verifier)
+
+	e := echo.New()
+	e.Use(RequestLogger(slog.New(slog.NewJSONHandler(&handlerLog, nil)), slog.New(slog.NewJSONHandler(io.Discard, nil))))
+	e.GET("/user", func(c echo.Context) error {
+		return c.NoContent(http.StatusOK)
+	}, a.UserMiddleware)
+
+	rec := do(e, "/user", map[string]string{echo.HeaderAuthorization: "Bearer not-a-jwt", echo.HeaderXRequestID: "req-43"})
+	require.Equal(t, http.StatusUnauthorized, rec.Code)
+
+	var line map[string]interface{}
+	require.NoError(t, json.Unmarshal(handlerLog.Bytes(), &line))
+	assert.Equal(t, "Rejected bearer token", line["msg"])
+	assert.Equal(t, "req-43", line["request_id"])
+}
+
+func TestRequestLoggerGeneratesRequestID(t *testing.T) {
+	var accessLog bytes.Buffer
+	e := echo.New()
+	e.Use(RequestLogger(slog.Default(), slog.New(slog.NewJSONHandler(&accessLog, nil))))
+	e.GET("/", func(c echo.Context) error {
+		return c.String(http.StatusOK, RequestID(c))
+	})
+
+	for _, incoming := range []string{"", "bad id\nwith newline", strings.Repeat("x", 200)} {
+		rec := do(e, "/", map[string]string{echo.HeaderXRequestID: incoming})
+		id := rec.Header().Get(echo.HeaderXRequestID)
+		assert.Len(t, id, 32, "id %q should be replaced", incoming)
+		assert.Equal(t, id, rec.Body.String())
+	}
+}
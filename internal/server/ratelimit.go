@@ -0,0 +1,81 @@
+package server
+
+import (
+	"avito/internal/config"
+	"avito/internal/server/middleware"
+	"context"
+	"errors"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+)
+
+// takeTokenScript refills the bucket at KEYS[1] with ARGV[1] tokens per
+// second up to ARGV[2] and takes one token. It uses the Redis clock, so
+// replicas with skewed clocks share the bucket correctly. The reply is
+// {allowed, remaining, retry_after_ms, reset_ms}.
+var takeTokenScript = redis.NewScript(`
+local rate = tonumber(ARGV[1])
+local burst = tonumber(ARGV[2])
+local time = redis.call('TIME')
+local now = tonumber(time[1]) * 1000 + math.floor(tonumber(time[2]) / 1000)
+
+local bucket = redis.call('HMGET', KEYS[1], 'tokens', 'updated')
+local tokens = tonumber(bucket[1])
+local updated = tonumber(bucket[2])
+if tokens == nil or updated == nil then
+	tokens = burst
+	updated = now
+end
+tokens = math.min(burst, tokens + math.max(0, now - updated) * rate / 1000)
+
+local allowed = 0
+local retry_after = 0
+if tokens >= 1 then
+	tokens = tokens - 1
+	allowed = 1
+else
+	retry_after = math.ceil((1 - tokens) * 1000 / rate)
+end
+local reset = math.ceil((burst - tokens) * 1000 / rate)
+
+redis.call('HSET', KEYS[1], 'tokens', tostring(tokens), 'updated', now)
+redis.call('PEXPIRE', KEYS[1], reset + 1000)
+return {allowed, math.floor(tokens), retry_after, reset}
+`)
+
+var errRateLimitBreakerOpen = errors.New("redis circuit breaker is open")
+
+// redisRateLimitStore shares token buckets between replicas through Redis.
+// It goes through the cache breaker, so while Redis is down the limiter
+// switches to in-memory buckets without waiting for timeouts.
+type redisRateLimitStore struct {
+	redis   *redis.Client
+	breaker *cacheBreaker
+}
+
+func newRedisRateLimitStore(client *redis.Client, breaker *cacheBreaker) *redisRateLimitStore {
+	return &redisRateLimitStore{redis: client, breaker: breaker}
+}
+
+func (r *redisRateLimitStore) Take(ctx context.Context, key string, limit config.RateLimit) (middleware.RateLimitResult, error) {
+	if !r.breaker.allow() {
+		return middleware.RateLimitResult{}, errRateLimitBreakerOpen
+	}
+
+	reply, err := takeTokenScript.Run(ctx, r.redis, []string{key}, limit.Rate, limit.Burst).Int64Slice()
+	r.breaker.done(err)
+	if err != nil {
+		return middleware.RateLimitResult{}, err
+	}
+	if len(reply) != 4 {
+		return middleware.RateLimitResult{}, errors.New("unexpected rate limit script reply")
+	}
+
+	return middleware.RateLimitResult{
+		Allowed:    reply[0] == 1,
+		Remaining:  int(reply[1]),
+		RetryAfter: time.Duration(reply[2]) * time.Millisecond,
+		Reset:      time.Duration(reply[3]) * time.Millisecond,
+	}, nil
+}
@@ -0,0 +1,64 @@
+package server
+
+import (
+	"avito/internal/config"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRedisRateLimitStoreSharesBuckets(t *testing.T) {
+	s, mr := newTestCacheServer(t)
+	mr.SetTime(time.Unix(1700000000, 0))
+	first := newRedisRateLimitStore(s.Redis, s.cacheBreaker)
+	second := newRedisRateLimitStore(s.Redis, s.cacheBreaker)
+	limit := config.RateLimit{Rate: 1, Burst: 2}
+	ctx := context.Background()
+
+	result, err := first.Take(ctx, "ratelimit:u1", limit)
+	require.NoError(t, err)
+	assert.True(t, result.Allowed)
+	assert.Equal(t, 1, result.Remaining)
+	assert.Equal(t, time.Second, result.Reset)
+
+	result, err = second.Take(ctx, "ratelimit:u1", limit)
+	require.NoError(t, err)
+	assert.True(t, result.Allowed)
+	assert.Equal(t, 0, result.Remaining)
+
+	result, err = first.Take(ctx, "ratelimit:u1", limit)
+	require.NoError(t, err)
+	assert.False(t, result.Allowed)
+	assert.Equal(t, time.Second, result.RetryAfter)
+	assert.Equal(t, 2*time.Second, result.Reset)
+	assert.True(t, mr.TTL("ratelimit:u1") > 0)
+}
+
+func TestRedisRateLimitStoreFailsWhileBreakerOpen(t *testing.T) {
+	s, mr := newTestCacheServer(t)
+	store := newRedisRateLimitStore(s.Redis, s.cacheBreaker)
+	mr.Close()
+
+	for i := 0; i < 3; i++ {
+		_, err := store.Take(context.Background(), "ratelimit:u1", config.RateLimit{Rate: 1, Burst: 1})
+		require.Error(t, err)
+	}
+	_, err := store.Take(context.Background(), "ratelimit:u1", config.RateLimit{Rate: 1, Burst: 1})
+	assert.ErrorIs(t, err, errRateLimitBreakerOpen)
+}
+
+func TestRedisRateLimitStoreIgnoresCancelledRequests(t *testing.T) {
+	s, _ := newTestCacheServer(t)
+	store := newRedisRateLimitStore(s.Redis, s.cacheBreaker)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	for i := 0; i < breakerFailureThreshold; i++ {
+		_, err := store.Take(ctx, "ratelimit:gone", config.RateLimit{Rate: 1, Burst: 1})
+		require.ErrorIs(t, err, context.Canceled)
+	}
+	assert.Equal(t, BreakerClosed, s.cacheBreaker.status().State, "Disconnected clients should not open the breaker")
+}
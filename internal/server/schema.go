@@ -0,0 +1,99 @@
+package server
+
+import (
+	"avito/internal/db"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/santhosh-tekuri/jsonschema/v5"
+	"gorm.io/gorm"
+)
+
+const featureSchemaURL = "mem://feature/schema.json"
+
+type ContentViolation struct {
+	Path    string `json:"path"`
+	Message string `json:"message"`
+}
+
+type ContentValidationError struct {
+	Message    string             `json:"message"`
+	Violations []ContentViolation `json:"violations"`
+}
+
+// compileFeatureSchema compiles a stored feature schema. References to other
+// documents are refused so a schema cannot make the server read files or
+// fetch URLs.
+func compileFeatureSchema(raw json.RawMessage) (*jsonschema.Schema, error) {
+	compiler := jsonschema.NewCompiler()
+	compiler.AssertFormat = true
+	compiler.LoadURL = func(url string) (io.ReadCloser, error) {
+		return nil, fmt.Errorf("external schema references are not supported: %s", url)
+	}
+	if err := compiler.AddResource(featureSchemaURL, bytes.NewReader(raw)); err != nil {
+		return nil, err
+	}
+	return compiler.Compile(featureSchemaURL)
+}
+
+// validateBannerContent checks content against the schema registered for the
+// feature, if any. A mismatch is reported as 400 with every violated path.
+func validateBannerContent(logger *slog.Logger, tx *gorm.DB, featureId int, content json.RawMessage) error {
+	var featureSchema db.FeatureSchema
+	if err := tx.First(&featureSchema, "feature_id = ?", featureId).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		}
+		logger.Error("Failed to load feature schema", "feature", featureId, "error", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to load feature schema: "+err.Error())
+	}
+
+	schema, err := compileFeatureSchema(featureSchema.Schema)
+	if err != nil {
+		logger.Error("Stored feature schema does not compile", "feature", featureId, "error", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "Invalid feature schema: "+err.Error())
+	}
+
+	var value interface{}
+	if err := json.Unmarshal(content, &value); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid banner content")
+	}
+
+	err = schema.Validate(value)
+	var validationErr *jsonschema.ValidationError
+	if errors.As(err, &validationErr) {
+		violations := collectViolations(validationErr, nil)
+		logger.Warn("Banner content does not match feature schema", "feature", featureId, "violations", violations)
+		return echo.NewHTTPError(http.StatusBadRequest, ContentValidationError{
+			Message:    "Banner content does not match the feature schema",
+			Violations: violations,
+		})
+	}
+	if err != nil {
+		logger.Error("Failed to validate banner content", "feature", featureId, "error", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to validate banner content: "+err.Error())
+	}
+	return nil
+}
+
+// collectViolations flattens the error tree into its leaves, which carry the
+// actual failed keywords.
+func collectViolations(ve *jsonschema.ValidationError, violations []ContentViolation) []ContentViolation {
+	if len(ve.Causes) == 0 {
+		path := ve.InstanceLocation
+		if path == "" {
+			path = "/"
+		}
+		return append(violations, ContentViolation{Path: path, Message: ve.Message})
+	}
+	for _, cause := range ve.Causes {
+		violations = collectViolations(cause, violations)
+	}
+	return violations
+}
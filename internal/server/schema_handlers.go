@@ -0,0 +1,78 @@
+package server
+
+import (
+	"avito/internal/db"
+	"avito/internal/generated"
+	"avito/internal/server/middleware"
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+func (s *Server) GetFeatureIdSchema(ctx echo.Context, id int, params generated.GetFeatureIdSchemaParams) error {
+	logger := middleware.Logger(ctx)
+
+	var featureSchema db.FeatureSchema
+	if err := s.requestDB(ctx).First(&featureSchema, "feature_id = ?", id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			logger.Warn("Feature schema not found", "feature", id)
+			return echo.NewHTTPError(http.StatusNotFound, "Schema not found")
+		}
+		logger.Error("Database error on retrieving feature schema", "error", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "Database error: "+err.Error())
+	}
+
+	return ctx.JSONBlob(http.StatusOK, featureSchema.Schema)
+}
+
+func (s *Server) PutFeatureIdSchema(ctx echo.Context, id int, params generated.PutFeatureIdSchemaParams) error {
+	logger := middleware.Logger(ctx)
+
+	var jsonBody generated.PutFeatureIdSchemaJSONBody
+	if err := ctx.Bind(&jsonBody); err != nil {
+		logger.Error("Failed to bind JSON body for feature schema", "error", err)
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request body")
+	}
+
+	raw, err := json.Marshal(jsonBody)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request body")
+	}
+	if _, err := compileFeatureSchema(raw); err != nil {
+		logger.Warn("Rejected invalid feature schema", "feature", id, "error", err)
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid JSON Schema: "+err.Error())
+	}
+
+	featureSchema := db.FeatureSchema{FeatureID: id, Schema: raw}
+	if err := s.requestDB(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "feature_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"schema", "updated_at"}),
+	}).Create(&featureSchema).Error; err != nil {
+		logger.Error("Failed to save feature schema", "feature", id, "error", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to save feature schema: "+err.Error())
+	}
+
+	logger.Info("Feature schema saved", "feature", id)
+	return ctx.String(http.StatusOK, "OK")
+}
+
+func (s *Server) DeleteFeatureIdSchema(ctx echo.Context, id int, params generated.DeleteFeatureIdSchemaParams) error {
+	logger := middleware.Logger(ctx)
+
+	result := s.requestDB(ctx).Delete(&db.FeatureSchema{}, "feature_id = ?", id)
+	if result.Error != nil {
+		logger.Error("Failed to delete feature schema", "feature", id, "error", result.Error)
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to delete feature schema: "+result.Error.Error())
+	}
+	if result.RowsAffected == 0 {
+		logger.Warn("Feature schema not found", "feature", id)
+		return echo.NewHTTPError(http.StatusNotFound, "Schema not found")
+	}
+
+	logger.Info("Feature schema deleted", "feature", id)
+	return ctx.NoContent(http.StatusNoContent)
+}
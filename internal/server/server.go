@@ -1,13 +1,25 @@
 package server
 
 import (
+	"avito/internal/auth"
+	"avito/internal/config"
 	"avito/internal/db"
+	"avito/internal/metrics"
+	"avito/internal/server/middleware"
+	"avito/internal/tracing"
+	"context"
+	"crypto/tls"
+	"errors"
 	"log/slog"
-	"os"
+	"sync"
+	"time"
 
 	"fmt"
 
 	"github.com/go-redis/redis/v8"
+	"github.com/labstack/echo/v4"
+	"go.opentelemetry.io/otel"
+	"golang.org/x/sync/singleflight"
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
 )
@@ -16,29 +28,146 @@ type Server struct {
 	DB     *gorm.DB
 	Redis  *redis.Client
 	Logger *slog.Logger
-}
 
-func NewServer(dbUrl string, redisUrl string) (*Server, error) {
-	handler := slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{
-		Level:     slog.LevelDebug,
-		AddSource: true,
-	})
+	// Tokens resolves tokens for the auth middleware; TokenManager issues
+	// and revokes the tokens kept in Postgres.
+	Tokens       *auth.CachedStore
+	TokenManager auth.TokenManager
+
+	// RateLimiter limits the user banner endpoints per token, with buckets
+	// shared through Redis.
+	RateLimiter *middleware.RateLimiter
+
+	Metrics *metrics.Metrics
+
+	jobsWakeup chan struct{}
 
-	logger := slog.New(handler)
+	// stop is closed by Close to end the background goroutines tracked by
+	// background.
+	stop       chan struct{}
+	background sync.WaitGroup
 
-	database, err := gorm.Open(postgres.Open(dbUrl), &gorm.Config{})
+	bannerCacheTTL time.Duration
+	localCache     *localCache
+	bannerLoads    singleflight.Group
+	cacheBreaker   *cacheBreaker
+}
+
+// NewServer connects to Postgres and Redis as described by cfg, migrates the
+// schema and starts the background workers. The default slog logger is used
+// for logging and is expected to be configured by the caller.
+func NewServer(cfg config.Config) (*Server, error) {
+	database, err := gorm.Open(postgres.Open(cfg.Database.URL), &gorm.Config{})
 	if err != nil {
 		return nil, fmt.Errorf("failed to connect to database: %v", err)
 	}
 
+	serverMetrics := metrics.New()
+	if err := database.Use(serverMetrics.GormPlugin()); err != nil {
+		return nil, fmt.Errorf("failed to register metrics plugin: %v", err)
+	}
+	if err := database.Use(tracing.GormPlugin(otel.GetTracerProvider())); err != nil {
+		return nil, fmt.Errorf("failed to register tracing plugin: %v", err)
+	}
+	sqlDB, err := database.DB()
+	if err != nil {
+		return nil, fmt.Errorf("failed to access database pool: %v", err)
+	}
+	sqlDB.SetMaxOpenConns(cfg.Database.MaxOpenConns)
+	sqlDB.SetMaxIdleConns(cfg.Database.MaxIdleConns)
+	sqlDB.SetConnMaxLifetime(cfg.Database.ConnMaxLifetime)
+	sqlDB.SetConnMaxIdleTime(cfg.Database.ConnMaxIdleTime)
+	serverMetrics.RegisterDBPool(sqlDB, "postgres")
+
 	err = db.Migrate(database)
 	if err != nil {
 		return nil, fmt.Errorf("failed to migrate database: %v", err)
 	}
 
-	rdb := redis.NewClient(&redis.Options{
-		Addr: redisUrl,
-	})
+	redisOptions := &redis.Options{
+		Addr:     cfg.Redis.Addr,
+		DB:       cfg.Redis.DB,
+		Password: cfg.Redis.Password,
+	}
+	if cfg.Redis.TLS {
+		redisOptions.TLSConfig = &tls.Config{
+			MinVersion: tls.VersionTLS12,
+			ServerName: cfg.Redis.TLSServerName,
+		}
+	}
+	rdb := redis.NewClient(redisOptions)
+	serverMetrics.RegisterRedisPool(rdb)
+	rdb.AddHook(tracing.RedisHook(otel.GetTracerProvider()))
+
+	breaker := newCacheBreaker(rdb, breakerFailureThreshold)
+	if err := rdb.Ping(context.Background()).Err(); err != nil {
+		slog.Warn("Redis is unavailable, starting without cache", "error", err)
+		breaker.trip(err)
+	}
 
-	return &Server{DB: database, Redis: rdb, Logger: logger}, nil
+	tokenManager := auth.NewPostgresStore(database)
+	tokenStores := []auth.TokenStore{tokenManager}
+	if cfg.Auth.TokensFile != "" {
+		staticStore, err := auth.LoadStaticStore(cfg.Auth.TokensFile)
+		if err != nil {
+			return nil, err
+		}
+		tokenStores = append([]auth.TokenStore{staticStore}, tokenStores...)
+	}
+
+	server := &Server{
+		DB:             database,
+		Redis:          rdb,
+		Logger:         slog.Default(),
+		Tokens:         auth.NewCachedStore(auth.NewMultiStore(tokenStores...), cfg.Cache.TokenTTL),
+		TokenManager:   tokenManager,
+		jobsWakeup:     make(chan struct{}, 1),
+		stop:           make(chan struct{}),
+		bannerCacheTTL: cfg.Cache.BannerTTL,
+		localCache:     newLocalCache(cfg.Cache.LocalSize, cfg.Cache.LocalTTL),
+		cacheBreaker:   breaker,
+		Metrics:        serverMetrics,
+	}
+	server.RateLimiter = middleware.NewRateLimiter(newRedisRateLimitStore(rdb, breaker), cfg.RateLimit)
+	server.goBackground(server.runJobWorker)
+	server.goBackground(func() { server.cacheBreaker.run(breakerProbeInterval, server.stop) })
+	server.goBackground(server.subscribeCacheInvalidation)
+	if cfg.Trash.Retention > 0 {
+		server.goBackground(func() { server.runTrashPurge(cfg.Trash.Retention, cfg.Trash.PurgeInterval) })
+	}
+
+	return server, nil
+}
+
+// requestDB binds the database handle to the request context, so queries
+// are traced as part of the request and stop when it is cancelled.
+func (s *Server) requestDB(ctx echo.Context) *gorm.DB {
+	return s.DB.WithContext(ctx.Request().Context())
+}
+
+func (s *Server) goBackground(fn func()) {
+	s.background.Add(1)
+	go func() {
+		defer s.background.Done()
+		fn()
+	}()
+}
+
+// Close stops the background workers, waiting for a running job to finish
+// its current batch, and then closes the database pool and the Redis
+// client. HTTP requests must be drained before calling it.
+func (s *Server) Close() error {
+	close(s.stop)
+	s.background.Wait()
+
+	var errs []error
+	if sqlDB, err := s.DB.DB(); err != nil {
+		errs = append(errs, err)
+	} else if err := sqlDB.Close(); err != nil {
+		errs = append(errs, fmt.Errorf("failed to close database: %v", err))
+	}
+	if err := s.Redis.Close(); err != nil {
+		errs = append(errs, fmt.Errorf("failed to close redis: %v", err))
+	}
+	return errors.Join(errs...)
 }
@@ -0,0 +1,108 @@
+package server
+
+import (
+	"avito/internal/auth"
+	"avito/internal/generated"
+	"avito/internal/server/middleware"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/labstack/echo/v4"
+)
+
+type TokenResponse struct {
+	ID        string     `json:"id"`
+	Role      string     `json:"role"`
+	ExpiresAt *time.Time `json:"expires_at"`
+	Revoked   bool       `json:"revoked"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+type TokenPostResponseCreated struct {
+	ID        string     `json:"id"`
+	Token     string     `json:"token"`
+	Role      string     `json:"role"`
+	ExpiresAt *time.Time `json:"expires_at"`
+}
+
+func (s *Server) GetTokens(ctx echo.Context, params generated.GetTokensParams) error {
+	logger := middleware.Logger(ctx)
+
+	tokens, err := s.TokenManager.List(ctx.Request().Context())
+	if err != nil {
+		logger.Error("Failed to fetch tokens", "error", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to fetch tokens")
+	}
+
+	response := make([]TokenResponse, len(tokens))
+	for i, token := range tokens {
+		response[i] = TokenResponse{
+			ID:        token.ID,
+			Role:      token.Role,
+			ExpiresAt: token.ExpiresAt,
+			Revoked:   token.Revoked,
+			CreatedAt: token.CreatedAt,
+		}
+	}
+
+	logger.Info("Successfully retrieved tokens", "count", len(tokens))
+	return ctx.JSON(http.StatusOK, response)
+}
+
+func (s *Server) PostTokens(ctx echo.Context, params generated.PostTokensParams) error {
+	logger := middleware.Logger(ctx)
+
+	var jsonBody generated.PostTokensJSONBody
+	if err := ctx.Bind(&jsonBody); err != nil {
+		logger.Error("Failed to bind JSON body for new token", "error", err)
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request body")
+	}
+
+	if jsonBody.Role == nil || !auth.ValidRole(string(*jsonBody.Role)) {
+		logger.Warn("Missing or unknown role for new token", "Role", jsonBody.Role)
+		return echo.NewHTTPError(http.StatusBadRequest, "Role must be either admin or user")
+	}
+
+	var expiresAt *time.Time
+	if jsonBody.TtlSeconds != nil {
+		if *jsonBody.TtlSeconds <= 0 {
+			return echo.NewHTTPError(http.StatusBadRequest, "TtlSeconds must be positive")
+		}
+		expiry := time.Now().Add(time.Duration(*jsonBody.TtlSeconds) * time.Second)
+		expiresAt = &expiry
+	}
+
+	raw, token, err := s.TokenManager.Mint(ctx.Request().Context(), string(*jsonBody.Role), expiresAt)
+	if err != nil {
+		logger.Error("Failed to mint token", "error", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to mint token: "+err.Error())
+	}
+
+	logger.Info("Token minted successfully", "tokenID", token.ID, "role", token.Role)
+	return ctx.JSON(http.StatusCreated, TokenPostResponseCreated{
+		ID:        token.ID,
+		Token:     raw,
+		Role:      token.Role,
+		ExpiresAt: token.ExpiresAt,
+	})
+}
+
+func (s *Server) DeleteTokensId(ctx echo.Context, id string, params generated.DeleteTokensIdParams) error {
+	logger := middleware.Logger(ctx)
+
+	if err := s.TokenManager.Revoke(ctx.Request().Context(), id); err != nil {
+		if errors.Is(err, auth.ErrTokenNotFound) {
+			logger.Warn("Token not found during revoke", "tokenID", id)
+			return echo.NewHTTPError(http.StatusNotFound, "Token not found")
+		}
+		logger.Error("Failed to revoke token", "tokenID", id, "error", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to revoke token")
+	}
+
+	s.Tokens.Forget(id)
+	s.announceTokenRevocation(ctx.Request().Context(), id)
+
+	logger.Info("Token revoked successfully", "tokenID", id)
+	return ctx.NoContent(http.StatusNoContent)
+}
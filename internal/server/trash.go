@@ -0,0 +1,198 @@
+package server
+
+import (
+	"avito/internal/db"
+	"avito/internal/generated"
+	"avito/internal/server/middleware"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// PostBannerIdRestore brings a banner back from the trash with the feature
+// and tags of its latest version. It fails with 409 when one of the pairs
+// was taken by another banner in the meantime, or when the banner has no
+// version to take them from.
+func (s *Server) PostBannerIdRestore(ctx echo.Context, id int, params generated.PostBannerIdRestoreParams) error {
+	logger := middleware.Logger(ctx)
+
+	tx := s.requestDB(ctx).Begin()
+	if tx.Error != nil {
+		logger.Error("Failed to start database transaction", "error", tx.Error)
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to start database transaction")
+	}
+
+	var banner db.Banner
+	if err := tx.Unscoped().Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("deleted_at IS NOT NULL").First(&banner, id).Error; err != nil {
+		tx.Rollback()
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			logger.Warn("Deleted banner not found during restore", "bannerID", id)
+			return echo.NewHTTPError(http.StatusNotFound, "Deleted banner not found")
+		}
+		logger.Error("Database error on retrieving banner", "error", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "Database error: "+err.Error())
+	}
+
+	var version db.BannerVersion
+	if err := tx.Where("banner_id = ?", id).Order("version DESC").First(&version).Error; err != nil {
+		tx.Rollback()
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			logger.Warn("Deleted banner has no version to restore from", "bannerID", id)
+			return echo.NewHTTPError(http.StatusConflict, "Deleted banner has no version to restore from")
+		}
+		logger.Error("Database error on retrieving banner version", "error", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "Database error: "+err.Error())
+	}
+
+	restoredTags := make([]db.BannerFeatureTag, 0, len(version.TagIDs))
+	for _, tagId := range version.TagIDs {
+		bftEntry := db.BannerFeatureTag{
+			BannerID:  banner.ID,
+			FeatureID: version.FeatureID,
+			TagID:     int(tagId),
+		}
+		if err := tx.Create(&bftEntry).Error; err != nil {
+			tx.Rollback()
+			if isDuplicateEntryError(err) {
+				logger.Warn("Feature and tag combination of deleted banner is taken", "bannerID", id, "feature", version.FeatureID, "tag", tagId)
+				return echo.NewHTTPError(http.StatusConflict, "Duplicate feature and tag combination")
+			}
+			logger.Error("Failed to create banner feature tag", "error", err)
+			return echo.NewHTTPError(http.StatusInternalServerError, "Failed to create banner feature tag: "+err.Error())
+		}
+		restoredTags = append(restoredTags, bftEntry)
+	}
+
+	if err := tx.Unscoped().Model(&banner).Update("deleted_at", nil).Error; err != nil {
+		tx.Rollback()
+		logger.Error("Failed to restore banner", "bannerID", id, "error", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to restore banner: "+err.Error())
+	}
+
+	if err := recordAudit(tx, ctx, db.AuditBannerRestore, banner.ID, nil, pairsSnapshot(banner, restoredTags)); err != nil {
+		tx.Rollback()
+		logger.Error("Failed to record audit event", "bannerID", id, "error", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to record audit event: "+err.Error())
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		logger.Error("Failed to commit transaction", "bannerID", id, "error", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to commit transaction: "+err.Error())
+	}
+
+	s.invalidateBannerCache(ctx.Request().Context(), restoredTags)
+
+	logger.Info("Banner restored from trash", "bannerID", id, "pairs", len(restoredTags))
+	return ctx.NoContent(http.StatusNoContent)
+}
+
+// runTrashPurge periodically removes banners that have been in the trash for
+// longer than retention, together with their versions. Every replica runs
+// it; deleting the same rows twice is harmless.
+func (s *Server) runTrashPurge(retention, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if err := s.purgeTrash(time.Now().Add(-retention)); err != nil {
+			slog.Error("Failed to purge deleted banners", "error", err)
+		}
+
+		select {
+		case <-s.stop:
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// purgeTrash permanently deletes banners deleted before cutoff in batches of
+// jobBatchSize, each batch in its own transaction. The batch is selected and
+// locked inside the transaction, so a banner restored concurrently either
+// keeps its versions or is purged after all. Every purged banner is audited
+// as banner.purge by the system actor.
+func (s *Server) purgeTrash(cutoff time.Time) error {
+	purged := 0
+	for !s.stopping() {
+		var bannerIDs []uint
+		err := s.DB.Transaction(func(tx *gorm.DB) error {
+			var banners []db.Banner
+			if err := tx.Unscoped().Clauses(clause.Locking{Strength: "UPDATE"}).
+				Where("deleted_at < ?", cutoff).Order("id").Limit(jobBatchSize).Find(&banners).Error; err != nil {
+				return fmt.Errorf("failed to select deleted banners: %v", err)
+			}
+			if len(banners) == 0 {
+				return nil
+			}
+			bannerIDs = make([]uint, len(banners))
+			for i, banner := range banners {
+				bannerIDs[i] = banner.ID
+			}
+			if err := auditPurge(tx, banners); err != nil {
+				return fmt.Errorf("failed to record audit events: %v", err)
+			}
+			if err := tx.Where("banner_id IN ?", bannerIDs).Delete(&db.BannerVersion{}).Error; err != nil {
+				return fmt.Errorf("failed to purge batch: %v", err)
+			}
+			if err := tx.Unscoped().Delete(&db.Banner{}, bannerIDs).Error; err != nil {
+				return fmt.Errorf("failed to purge batch: %v", err)
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		if len(bannerIDs) == 0 {
+			break
+		}
+		purged += len(bannerIDs)
+	}
+
+	if purged > 0 {
+		slog.Info("Purged deleted banners", "count", purged, "cutoff", cutoff)
+	}
+	return nil
+}
+
+// auditPurge records the purge of banners with the feature and tags of their
+// latest versions, since their pairs were released when they were deleted.
+func auditPurge(tx *gorm.DB, banners []db.Banner) error {
+	bannerIDs := make([]uint, len(banners))
+	for i, banner := range banners {
+		bannerIDs[i] = banner.ID
+	}
+	var versions []db.BannerVersion
+	if err := tx.Select("DISTINCT ON (banner_id) *").Where("banner_id IN ?", bannerIDs).
+		Order("banner_id, version DESC").Find(&versions).Error; err != nil {
+		return err
+	}
+	latest := make(map[uint]db.BannerVersion, len(versions))
+	for _, version := range versions {
+		latest[version.BannerID] = version
+	}
+
+	for _, banner := range banners {
+		version := latest[banner.ID]
+		tagIds := make([]int, len(version.TagIDs))
+		for i, tagId := range version.TagIDs {
+			tagIds[i] = int(tagId)
+		}
+		err := writeAuditEvent(tx, db.AuditEvent{
+			ActorTokenID: db.AuditSystemActor,
+			ActorRole:    db.AuditSystemActor,
+			Action:       db.AuditBannerPurge,
+			BannerID:     banner.ID,
+		}, newAuditSnapshot(banner, version.FeatureID, tagIds), nil)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
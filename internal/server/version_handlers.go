@@ -0,0 +1,217 @@
+package server
+
+import (
+	"avito/internal/db"
+	"avito/internal/generated"
+	"avito/internal/server/middleware"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const defaultVersionsLimit = 10
+
+type BannerVersionResponse struct {
+	Version     int             `json:"version"`
+	Content     json.RawMessage `json:"content"`
+	IsActive    bool            `json:"is_active"`
+	ActiveFrom  *time.Time      `json:"active_from"`
+	ActiveUntil *time.Time      `json:"active_until"`
+	FeatureID   int             `json:"feature_id"`
+	TagIds      []int           `json:"tag_ids"`
+	CreatedAt   time.Time       `json:"created_at"`
+}
+
+func (s *Server) GetBannerVersionsId(ctx echo.Context, id int, params generated.GetBannerVersionsIdParams) error {
+	logger := middleware.Logger(ctx)
+
+	limit := defaultVersionsLimit
+	if params.Limit != nil {
+		if *params.Limit <= 0 {
+			return echo.NewHTTPError(http.StatusBadRequest, "Limit must be positive")
+		}
+		limit = *params.Limit
+	}
+
+	// Banners in the trash keep their versions, which restore works from.
+	var banner db.Banner
+	if err := s.requestDB(ctx).Unscoped().First(&banner, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			logger.Warn("Banner not found while listing versions", "bannerID", id)
+			return echo.NewHTTPError(http.StatusNotFound, "Banner not found")
+		}
+		logger.Error("Database error on retrieving banner", "error", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "Database error: "+err.Error())
+	}
+
+	var versions []db.BannerVersion
+	if err := s.requestDB(ctx).Where("banner_id = ?", id).Order("version desc").Limit(limit).Find(&versions).Error; err != nil {
+		logger.Error("Failed to fetch banner versions", "bannerID", id, "error", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to fetch banner versions")
+	}
+
+	response := make([]BannerVersionResponse, len(versions))
+	for i, version := range versions {
+		response[i] = BannerVersionResponse{
+			Version:     version.Version,
+			Content:     version.Content,
+			IsActive:    version.IsActive,
+			ActiveFrom:  version.ActiveFrom,
+			ActiveUntil: version.ActiveUntil,
+			FeatureID:   version.FeatureID,
+			TagIds:      make([]int, len(version.TagIDs)),
+			CreatedAt:   version.CreatedAt,
+		}
+		for j, tagId := range version.TagIDs {
+			response[i].TagIds[j] = int(tagId)
+		}
+	}
+
+	logger.Info("Successfully retrieved banner versions", "bannerID", id, "count", len(versions))
+	return ctx.JSON(http.StatusOK, response)
+}
+
+func (s *Server) PutBannerVersionsIdActivate(ctx echo.Context, id int, params generated.PutBannerVersionsIdActivateParams) error {
+	logger := middleware.Logger(ctx)
+	logger.Info("Starting transaction for banner rollback", "bannerID", id, "version", params.Version)
+	tx := s.requestDB(ctx).Begin()
+	if tx.Error != nil {
+		logger.Error("Failed to start database transaction", "error", tx.Error)
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to start database transaction")
+	}
+
+	var banner db.Banner
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&banner, id).Error; err != nil {
+		tx.Rollback()
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			logger.Warn("Banner not found during rollback", "bannerID", id)
+			return echo.NewHTTPError(http.StatusNotFound, "Banner not found")
+		}
+		logger.Error("Database error on retrieving banner", "error", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "Database error: "+err.Error())
+	}
+
+	var version db.BannerVersion
+	if err := tx.Where("banner_id = ? AND version = ?", id, params.Version).First(&version).Error; err != nil {
+		tx.Rollback()
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			logger.Warn("Banner version not found during rollback", "bannerID", id, "version", params.Version)
+			return echo.NewHTTPError(http.StatusNotFound, "Banner version not found")
+		}
+		logger.Error("Database error on retrieving banner version", "error", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "Database error: "+err.Error())
+	}
+
+	var previousTags []db.BannerFeatureTag
+	if err := tx.Where("banner_id = ?", id).Find(&previousTags).Error; err != nil {
+		tx.Rollback()
+		logger.Error("Failed to retrieve existing feature and tag associations", "bannerID", id, "error", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to retrieve existing feature and tag associations: "+err.Error())
+	}
+	before := pairsSnapshot(banner, previousTags)
+
+	banner.Content = version.Content
+	banner.IsActive = version.IsActive
+	banner.ActiveFrom = version.ActiveFrom
+	banner.ActiveUntil = version.ActiveUntil
+	if err := tx.Save(&banner).Error; err != nil {
+		tx.Rollback()
+		logger.Error("Failed to update banner", "bannerID", id, "error", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to update banner: "+err.Error())
+	}
+
+	if err := tx.Where("banner_id = ?", id).Delete(&db.BannerFeatureTag{}).Error; err != nil {
+		tx.Rollback()
+		logger.Error("Failed to delete existing banner feature tags", "bannerID", id, "error", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to delete existing banner feature tags: "+err.Error())
+	}
+
+	restoredTags := make([]db.BannerFeatureTag, 0, len(version.TagIDs))
+	tagIds := make([]int, 0, len(version.TagIDs))
+	for _, tagId := range version.TagIDs {
+		bftEntry := db.BannerFeatureTag{
+			BannerID:  banner.ID,
+			FeatureID: version.FeatureID,
+			TagID:     int(tagId),
+		}
+		if err := tx.Create(&bftEntry).Error; err != nil {
+			tx.Rollback()
+			if isDuplicateEntryError(err) {
+				logger.Warn("Restored feature and tag combination is taken", "feature", version.FeatureID, "tag", tagId)
+				return echo.NewHTTPError(http.StatusConflict, "Duplicate feature and tag combination")
+			}
+			logger.Error("Failed to create banner feature tag", "error", err)
+			return echo.NewHTTPError(http.StatusInternalServerError, "Failed to create banner feature tag: "+err.Error())
+		}
+		restoredTags = append(restoredTags, bftEntry)
+		tagIds = append(tagIds, bftEntry.TagID)
+	}
+
+	if err := snapshotBannerVersion(tx, banner, version.FeatureID, tagIds); err != nil {
+		tx.Rollback()
+		logger.Error("Failed to save banner version", "bannerID", id, "error", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to save banner version: "+err.Error())
+	}
+
+	if err := recordAudit(tx, ctx, db.AuditBannerRollback, banner.ID, before, newAuditSnapshot(banner, version.FeatureID, tagIds)); err != nil {
+		tx.Rollback()
+		logger.Error("Failed to record audit event", "bannerID", id, "error", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to record audit event: "+err.Error())
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		logger.Error("Failed to commit transaction", "bannerID", id, "error", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to commit transaction: "+err.Error())
+	}
+
+	s.invalidateBannerCache(ctx.Request().Context(), append(previousTags, restoredTags...))
+
+	logger.Info("Banner rollback completed successfully", "bannerID", id, "version", params.Version)
+	return ctx.String(http.StatusOK, "OK")
+}
+
+// snapshotBannerVersion records the current state of a banner as its next
+// version. It must run in the same transaction as the change it records,
+// with the banner row locked, so concurrent changes do not pick the same
+// version number.
+func snapshotBannerVersion(tx *gorm.DB, banner db.Banner, featureId int, tagIds []int) error {
+	var last int
+	if err := tx.Model(&db.BannerVersion{}).Where("banner_id = ?", banner.ID).
+		Select("COALESCE(MAX(version), 0)").Scan(&last).Error; err != nil {
+		return err
+	}
+
+	version := db.BannerVersion{
+		BannerID:    banner.ID,
+		Version:     last + 1,
+		Content:     banner.Content,
+		IsActive:    banner.IsActive,
+		ActiveFrom:  banner.ActiveFrom,
+		ActiveUntil: banner.ActiveUntil,
+		FeatureID:   featureId,
+		TagIDs:      make([]int64, len(tagIds)),
+	}
+	for i, tagId := range tagIds {
+		version.TagIDs[i] = int64(tagId)
+	}
+
+	return tx.Create(&version).Error
+}
+
+// snapshotBannerPairs records a banner with the feature and tags of its
+// current pairs. Deletes call it before removing the pairs, so a restore
+// finds them even for banners created before versions were kept.
+func snapshotBannerPairs(tx *gorm.DB, banner db.Banner, pairs []db.BannerFeatureTag) error {
+	var featureId int
+	tagIds := make([]int, 0, len(pairs))
+	for _, pair := range pairs {
+		featureId = pair.FeatureID
+		tagIds = append(tagIds, pair.TagID)
+	}
+	return snapshotBannerVersion(tx, banner, featureId, tagIds)
+}
@@ -0,0 +1,98 @@
+package tracing
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/go-redis/redis/v8"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+func newTestProvider(t *testing.T) (*sdktrace.TracerProvider, *tracetest.InMemoryExporter) {
+	exporter := tracetest.NewInMemoryExporter()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+	t.Cleanup(func() { provider.Shutdown(context.Background()) })
+	return provider, exporter
+}
+
+func TestMiddlewareContinuesIncomingTrace(t *testing.T) {
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	provider, exporter := newTestProvider(t)
+
+	e := echo.New()
+	e.Use(Middleware(provider))
+	var handlerSpan trace.SpanContext
+	e.GET("/banner/:id", func(c echo.Context) error {
+		handlerSpan = trace.SpanContextFromContext(c.Request().Context())
+		return echo.NewHTTPError(http.StatusInternalServerError, "Database error")
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/banner/7", nil)
+	req.Header.Set("traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	e.ServeHTTP(httptest.NewRecorder(), req)
+
+	spans := exporter.GetSpans()
+	require.Len(t, spans, 1)
+	span := spans[0]
+	assert.Equal(t, "GET /banner/:id", span.Name)
+	assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", span.SpanContext.TraceID().String())
+	assert.Equal(t, "00f067aa0ba902b7", span.Parent.SpanID().String())
+	assert.Equal(t, span.SpanContext.SpanID(), handlerSpan.SpanID())
+	assert.Equal(t, codes.Error, span.Status.Code)
+}
+
+func TestGormPluginRecordsChildSpans(t *testing.T) {
+	provider, exporter := newTestProvider(t)
+
+	database, err := gorm.Open(postgres.Open("postgres://localhost/test"), &gorm.Config{DryRun: true, DisableAutomaticPing: true})
+	require.NoError(t, err)
+	require.NoError(t, database.Use(GormPlugin(provider)))
+
+	ctx, parent := provider.Tracer("test").Start(context.Background(), "request")
+	var count int64
+	require.NoError(t, database.WithContext(ctx).Table("banners").Where("id = ?", 1).Count(&count).Error)
+	parent.End()
+
+	spans := exporter.GetSpans()
+	require.Len(t, spans, 2)
+	query := spans[0]
+	assert.Equal(t, "gorm.query", query.Name)
+	assert.Equal(t, parent.SpanContext().SpanID(), query.Parent.SpanID())
+	assert.Contains(t, query.Attributes, attribute.String("db.sql.table", "banners"))
+}
+
+func TestRedisHookIgnoresMisses(t *testing.T) {
+	provider, exporter := newTestProvider(t)
+	mr := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	t.Cleanup(func() { client.Close() })
+	client.AddHook(RedisHook(provider))
+
+	ctx, parent := provider.Tracer("test").Start(context.Background(), "request")
+	require.ErrorIs(t, client.Get(ctx, "banner:1:1").Err(), redis.Nil)
+	mr.SetError("READONLY")
+	require.Error(t, client.Set(ctx, "banner:1:1", "{}", 0).Err())
+	parent.End()
+
+	spans := exporter.GetSpans()
+	require.Len(t, spans, 3)
+	assert.Equal(t, "redis.get", spans[0].Name)
+	assert.Equal(t, parent.SpanContext().SpanID(), spans[0].Parent.SpanID())
+	assert.Equal(t, codes.Unset, spans[0].Status.Code)
+	assert.Equal(t, "redis.set", spans[1].Name)
+	assert.Equal(t, codes.Error, spans[1].Status.Code)
+}
@@ -1,30 +1,154 @@
 package main
 
 import (
+	"avito/internal/auth"
+	"avito/internal/config"
 	sv "avito/internal/server"
+	"avito/internal/server/middleware"
+	"avito/internal/tracing"
+	"context"
+	"crypto/rsa"
+	"flag"
+	"log/slog"
 	"net/http"
 	"os"
+	"os/signal"
+	"syscall"
 
 	"github.com/labstack/echo/v4"
+	"go.opentelemetry.io/otel"
 )
 
 func main() {
+	configFile := flag.String("config", os.Getenv("CONFIG_FILE"), "path to a YAML or JSON config file")
+	flag.Parse()
 
-	dbUrl := os.Getenv("DATABASE_URL")
-	redisURL := os.Getenv("REDIS_URL")
+	// The migrate subcommand opens only the database, so the rest of the
+	// configuration, such as the Redis address, is not required for it.
+	migrate := flag.Arg(0) == "migrate"
+	cfg, err := config.Read(*configFile)
+	if err == nil && migrate {
+		err = cfg.Database.Validate()
+	} else if err == nil {
+		err = cfg.Validate()
+	}
+	if err != nil {
+		slog.Error("Invalid configuration", "error", err)
+		os.Exit(1)
+	}
+	slog.SetDefault(cfg.Log.NewLogger(os.Stderr))
 
-	server, err := sv.NewServer(dbUrl, redisURL)
+	if migrate {
+		if err := runMigrate(cfg.Database, flag.Args()[1:]); err != nil {
+			slog.Error("Migration failed", "error", err)
+			os.Exit(1)
+		}
+		return
+	}
 
+	shutdownTracing, err := tracing.Setup(context.Background(), cfg.Tracing)
 	if err != nil {
-		return
+		slog.Error("Failed to configure tracing", "error", err)
+		os.Exit(1)
+	}
+
+	server, err := sv.NewServer(cfg)
+	if err != nil {
+		slog.Error("Failed to initialize server", "error", err)
+		os.Exit(1)
+	}
+
+	verifier, err := loadJWTVerifier(cfg.Auth)
+	if err != nil {
+		slog.Error("Failed to configure JWT authentication", "error", err)
+		os.Exit(1)
+	}
+
+	var tokens auth.TokenStore
+	if cfg.Auth.LegacyTokens {
+		tokens = server.Tokens
 	}
 
 	e := echo.New()
+	e.Use(tracing.Middleware(otel.GetTracerProvider()))
+	e.Use(middleware.RequestLogger(server.Logger, cfg.Log.NewAccessLogger(os.Stderr)))
+
+	sv.RegisterHandlersWithAuth(e, server, middleware.NewAuth(tokens, verifier), server.RateLimiter)
+	sv.RegisterHealthHandlers(e, server)
+	e.Use(server.Metrics.Middleware())
+
+	httpServer := &http.Server{
+		Addr:         cfg.HTTP.ListenAddr,
+		ReadTimeout:  cfg.HTTP.ReadTimeout,
+		WriteTimeout: cfg.HTTP.WriteTimeout,
+		IdleTimeout:  cfg.HTTP.IdleTimeout,
+	}
 
-	sv.RegisterHandlersWithAuth(e, server)
-	e.Use()
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
 
-	if err := e.Start(":8080"); err != nil && err != http.ErrServerClosed {
-		e.Logger.Fatal("Shutting down the server", err)
+	serverErr := make(chan error, 1)
+	go func() {
+		serverErr <- e.StartServer(httpServer)
+	}()
+
+	select {
+	case err := <-serverErr:
+		if err != nil && err != http.ErrServerClosed {
+			slog.Error("HTTP server failed", "error", err)
+			os.Exit(1)
+		}
+	case <-ctx.Done():
+		slog.Info("Shutdown signal received, draining requests", "timeout", cfg.HTTP.ShutdownTimeout)
+	}
+	stop()
+
+	// Stop accepting connections and wait for in-flight requests before the
+	// database and Redis go away under them.
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), cfg.HTTP.ShutdownTimeout)
+	exitCode := 0
+	if err := e.Shutdown(shutdownCtx); err != nil {
+		slog.Error("Failed to drain in-flight requests", "error", err)
+		exitCode = 1
+	}
+	cancel()
+	if err := server.Close(); err != nil {
+		slog.Error("Failed to close server resources", "error", err)
+		exitCode = 1
+	}
+	// Flush the spans of the drained requests.
+	tracingCtx, cancel := context.WithTimeout(context.Background(), cfg.HTTP.ShutdownTimeout)
+	if err := shutdownTracing(tracingCtx); err != nil {
+		slog.Error("Failed to flush traces", "error", err)
+		exitCode = 1
+	}
+	cancel()
+	slog.Info("Server stopped")
+	os.Exit(exitCode)
+}
+
+// loadJWTVerifier builds a verifier from the auth settings. It returns nil
+// when no key is configured, which disables bearer tokens.
+func loadJWTVerifier(cfg config.AuthConfig) (*auth.JWTVerifier, error) {
+	secret, err := auth.ReadKey(cfg.JWTHS256Secret, cfg.JWTHS256SecretFile)
+	if err != nil {
+		return nil, err
 	}
+
+	publicKeyPEM, err := auth.ReadKey(cfg.JWTRS256PublicKey, cfg.JWTRS256PublicKeyFile)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(secret) == 0 && len(publicKeyPEM) == 0 {
+		return nil, nil
+	}
+
+	var publicKey *rsa.PublicKey
+	if len(publicKeyPEM) > 0 {
+		if publicKey, err = auth.ParseRSAPublicKey(publicKeyPEM); err != nil {
+			return nil, err
+		}
+	}
+
+	return auth.NewJWTVerifier(secret, publicKey)
 }
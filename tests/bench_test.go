@@ -0,0 +1,54 @@
+package tests
+
+import (
+	"avito/internal/generated"
+	"context"
+	"net/http"
+	"testing"
+)
+
+const benchBanners = 1000
+
+// seedBenchBanners makes sure benchBanners banners exist for the benchmark.
+// Each banner has its own feature, so reruns only hit conflicts.
+func seedBenchBanners(b *testing.B, client *generated.ClientWithResponses, token string) {
+	ctx := context.Background()
+	for i := 0; i < benchBanners; i++ {
+		resp, err := client.PostBannerWithResponse(ctx, &generated.PostBannerParams{Token: &token}, generated.PostBannerJSONRequestBody{
+			Content:   &map[string]interface{}{"title": "Benchmark Banner"},
+			FeatureId: ptrToInt(100000 + i),
+			IsActive:  ptrToBool(true),
+			TagIds:    &[]int{1, 2, 3},
+		})
+		if err != nil {
+			b.Fatalf("Failed to create banner: %v", err)
+		}
+		if resp.StatusCode() != http.StatusCreated && resp.StatusCode() != http.StatusConflict {
+			b.Fatalf("Unexpected status while seeding banners: %d", resp.StatusCode())
+		}
+	}
+}
+
+func BenchmarkGetBanner(b *testing.B) {
+	client, err := generated.NewClientWithResponses(getTestUrl())
+	if err != nil {
+		b.Fatalf("Failed to create client: %v", err)
+	}
+
+	adminToken := "admin1"
+	seedBenchBanners(b, client, adminToken)
+
+	ctx := context.Background()
+	params := &generated.GetBannerParams{Limit: ptrToInt(benchBanners), Token: &adminToken}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		resp, err := client.GetBannerWithResponse(ctx, params)
+		if err != nil {
+			b.Fatalf("Failed to fetch banners: %v", err)
+		}
+		if resp.StatusCode() != http.StatusOK {
+			b.Fatalf("Unexpected status: %d", resp.StatusCode())
+		}
+	}
+}
@@ -3,11 +3,15 @@ package tests
 import (
 	"avito/internal/generated"
 	"context"
+	"encoding/json"
 	"fmt"
+	"io"
 	"log"
 	"net/http"
 	"os"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -196,6 +200,897 @@ func TestPostDuplicateBanner(t *testing.T) {
 
 }
 
+func TestBannerVersionRollback(t *testing.T) {
+	client, err := generated.NewClientWithResponses(getTestUrl())
+	require.NoError(t, err, "Failed to create client")
+
+	ctx := context.Background()
+	adminToken := "admin1"
+
+	postResp, err := client.PostBannerWithResponse(ctx, &generated.PostBannerParams{Token: &adminToken}, generated.PostBannerJSONRequestBody{
+		Content:   &map[string]interface{}{"title": "Original Title"},
+		FeatureId: ptrToInt(300),
+		IsActive:  ptrToBool(true),
+		TagIds:    &[]int{301},
+	})
+	require.NoError(t, err, "Failed to create banner")
+	require.Equal(t, http.StatusCreated, postResp.HTTPResponse.StatusCode, "Banner creation failed")
+	bannerID := *postResp.JSON201.BannerId
+
+	patchResp, err := client.PatchBannerIdWithResponse(ctx, bannerID, &generated.PatchBannerIdParams{Token: &adminToken}, generated.PatchBannerIdJSONRequestBody{
+		Content: &map[string]interface{}{"title": "Broken Title"},
+		TagIds:  &[]int{301, 302},
+	})
+	require.NoError(t, err, "Error while patching banner")
+	require.Equal(t, http.StatusOK, patchResp.HTTPResponse.StatusCode, "Banner patching failed")
+
+	versionsResp, err := client.GetBannerVersionsIdWithResponse(ctx, bannerID, &generated.GetBannerVersionsIdParams{Token: &adminToken})
+	require.NoError(t, err, "Failed to list banner versions")
+	require.Equal(t, http.StatusOK, versionsResp.HTTPResponse.StatusCode)
+	require.Len(t, *versionsResp.JSON200, 2)
+	assert.Equal(t, 2, *(*versionsResp.JSON200)[0].Version)
+	assert.Equal(t, []int{301, 302}, *(*versionsResp.JSON200)[0].TagIds)
+
+	activateResp, err := client.PutBannerVersionsIdActivateWithResponse(ctx, bannerID, &generated.PutBannerVersionsIdActivateParams{Token: &adminToken, Version: 1})
+	require.NoError(t, err, "Failed to activate banner version")
+	require.Equal(t, http.StatusOK, activateResp.HTTPResponse.StatusCode)
+
+	userResp, err := client.GetUserBannerWithResponse(ctx, &generated.GetUserBannerParams{FeatureId: 300, TagId: 301, Token: &adminToken})
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, userResp.HTTPResponse.StatusCode)
+	assert.Equal(t, &map[string]interface{}{"title": "Original Title"}, userResp.JSON200)
+
+	userResp, err = client.GetUserBannerWithResponse(ctx, &generated.GetUserBannerParams{FeatureId: 300, TagId: 302, Token: &adminToken})
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusNotFound, userResp.HTTPResponse.StatusCode)
+
+	missingResp, err := client.PutBannerVersionsIdActivateWithResponse(ctx, bannerID, &generated.PutBannerVersionsIdActivateParams{Token: &adminToken, Version: 42})
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusNotFound, missingResp.HTTPResponse.StatusCode)
+}
+
+func TestInactiveBannerHiddenFromUsers(t *testing.T) {
+	client, err := generated.NewClientWithResponses(getTestUrl())
+	require.NoError(t, err, "Failed to create client")
+
+	ctx := context.Background()
+	adminToken := "admin1"
+	userToken := "user1"
+
+	postResp, err := client.PostBannerWithResponse(ctx, &generated.PostBannerParams{Token: &adminToken}, generated.PostBannerJSONRequestBody{
+		Content:   &map[string]interface{}{"title": "Hidden Banner"},
+		FeatureId: ptrToInt(310),
+		IsActive:  ptrToBool(false),
+		TagIds:    &[]int{311},
+	})
+	require.NoError(t, err, "Failed to create banner")
+	require.Equal(t, http.StatusCreated, postResp.HTTPResponse.StatusCode, "Banner creation failed")
+
+	params := generated.GetUserBannerParams{FeatureId: 310, TagId: 311, UseLastRevision: ptrToBool(true)}
+
+	params.Token = &adminToken
+	adminResp, err := client.GetUserBannerWithResponse(ctx, &params)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, adminResp.HTTPResponse.StatusCode, "Admin should see inactive banners")
+
+	params.Token = &userToken
+	userResp, err := client.GetUserBannerWithResponse(ctx, &params)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusNotFound, userResp.HTTPResponse.StatusCode, "User should not see inactive banners")
+
+	params.UseLastRevision = nil
+	cachedResp, err := client.GetUserBannerWithResponse(ctx, &params)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusNotFound, cachedResp.HTTPResponse.StatusCode, "Cached inactive banner should not be shown to users")
+}
+
+func TestBannerMutationInvalidatesCache(t *testing.T) {
+	client, err := generated.NewClientWithResponses(getTestUrl())
+	require.NoError(t, err, "Failed to create client")
+
+	ctx := context.Background()
+	adminToken := "admin1"
+	userToken := "user1"
+
+	postResp, err := client.PostBannerWithResponse(ctx, &generated.PostBannerParams{Token: &adminToken}, generated.PostBannerJSONRequestBody{
+		Content:   &map[string]interface{}{"title": "Cached Title"},
+		FeatureId: ptrToInt(320),
+		IsActive:  ptrToBool(true),
+		TagIds:    &[]int{321},
+	})
+	require.NoError(t, err, "Failed to create banner")
+	require.Equal(t, http.StatusCreated, postResp.HTTPResponse.StatusCode, "Banner creation failed")
+	bannerID := *postResp.JSON201.BannerId
+
+	params := generated.GetUserBannerParams{FeatureId: 320, TagId: 321, Token: &userToken}
+
+	userResp, err := client.GetUserBannerWithResponse(ctx, &params)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, userResp.HTTPResponse.StatusCode)
+	assert.Equal(t, &map[string]interface{}{"title": "Cached Title"}, userResp.JSON200)
+
+	patchResp, err := client.PatchBannerIdWithResponse(ctx, bannerID, &generated.PatchBannerIdParams{Token: &adminToken}, generated.PatchBannerIdJSONRequestBody{
+		Content: &map[string]interface{}{"title": "Fresh Title"},
+	})
+	require.NoError(t, err, "Error while patching banner")
+	require.Equal(t, http.StatusOK, patchResp.HTTPResponse.StatusCode, "Banner patching failed")
+
+	userResp, err = client.GetUserBannerWithResponse(ctx, &params)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, userResp.HTTPResponse.StatusCode)
+	assert.Equal(t, &map[string]interface{}{"title": "Fresh Title"}, userResp.JSON200, "Patched banner should not be served from stale cache")
+
+	deleteResp, err := client.DeleteBannerIdWithResponse(ctx, bannerID, &generated.DeleteBannerIdParams{Token: &adminToken})
+	require.NoError(t, err)
+	require.Equal(t, http.StatusNoContent, deleteResp.HTTPResponse.StatusCode)
+
+	userResp, err = client.GetUserBannerWithResponse(ctx, &params)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusNotFound, userResp.HTTPResponse.StatusCode, "Deleted banner should not be served from cache")
+}
+
+func TestTokenLifecycle(t *testing.T) {
+	client, err := generated.NewClientWithResponses(getTestUrl())
+	require.NoError(t, err, "Failed to create client")
+
+	ctx := context.Background()
+	adminToken := "admin1"
+
+	postResp, err := client.PostBannerWithResponse(ctx, &generated.PostBannerParams{Token: &adminToken}, generated.PostBannerJSONRequestBody{
+		Content:   &map[string]interface{}{"title": "Token Banner"},
+		FeatureId: ptrToInt(330),
+		IsActive:  ptrToBool(true),
+		TagIds:    &[]int{331},
+	})
+	require.NoError(t, err, "Failed to create banner")
+	require.Equal(t, http.StatusCreated, postResp.HTTPResponse.StatusCode, "Banner creation failed")
+
+	role := generated.User
+	mintResp, err := client.PostTokensWithResponse(ctx, &generated.PostTokensParams{Token: &adminToken}, generated.PostTokensJSONRequestBody{
+		Role:       &role,
+		TtlSeconds: ptrToInt(3600),
+	})
+	require.NoError(t, err, "Failed to mint token")
+	require.Equal(t, http.StatusCreated, mintResp.HTTPResponse.StatusCode)
+	userToken := *mintResp.JSON201.Token
+	tokenID := *mintResp.JSON201.Id
+
+	params := generated.GetUserBannerParams{FeatureId: 330, TagId: 331, Token: &userToken}
+	userResp, err := client.GetUserBannerWithResponse(ctx, &params)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, userResp.HTTPResponse.StatusCode, "Minted user token should be accepted")
+
+	forbiddenResp, err := client.GetBannerWithResponse(ctx, &generated.GetBannerParams{Token: &userToken})
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusForbidden, forbiddenResp.HTTPResponse.StatusCode, "User token should not reach admin endpoints")
+
+	listResp, err := client.GetTokensWithResponse(ctx, &generated.GetTokensParams{Token: &adminToken})
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, listResp.HTTPResponse.StatusCode)
+	found := false
+	for _, token := range *listResp.JSON200 {
+		if *token.Id == tokenID {
+			found = true
+			assert.False(t, *token.Revoked)
+		}
+	}
+	assert.True(t, found, "Minted token should be listed")
+
+	revokeResp, err := client.DeleteTokensIdWithResponse(ctx, tokenID, &generated.DeleteTokensIdParams{Token: &adminToken})
+	require.NoError(t, err)
+	require.Equal(t, http.StatusNoContent, revokeResp.HTTPResponse.StatusCode)
+
+	userResp, err = client.GetUserBannerWithResponse(ctx, &params)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusUnauthorized, userResp.HTTPResponse.StatusCode, "Revoked token should be rejected")
+}
+
+func TestDeleteBannersByFeature(t *testing.T) {
+	client, err := generated.NewClientWithResponses(getTestUrl())
+	require.NoError(t, err, "Failed to create client")
+
+	ctx := context.Background()
+	adminToken := "admin1"
+
+	var bannerIDs []int
+	for _, tagId := range []int{341, 342, 343} {
+		postResp, err := client.PostBannerWithResponse(ctx, &generated.PostBannerParams{Token: &adminToken}, generated.PostBannerJSONRequestBody{
+			Content:   &map[string]interface{}{"title": fmt.Sprintf("Retired %d", tagId)},
+			FeatureId: ptrToInt(340),
+			IsActive:  ptrToBool(true),
+			TagIds:    &[]int{tagId},
+		})
+		require.NoError(t, err, "Failed to create banner")
+		require.Equal(t, http.StatusCreated, postResp.HTTPResponse.StatusCode, "Banner creation failed")
+		bannerIDs = append(bannerIDs, *postResp.JSON201.BannerId)
+	}
+
+	userResp, err := client.GetUserBannerWithResponse(ctx, &generated.GetUserBannerParams{FeatureId: 340, TagId: 341, Token: &adminToken})
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, userResp.HTTPResponse.StatusCode)
+
+	badResp, err := client.DeleteBannerWithResponse(ctx, &generated.DeleteBannerParams{Token: &adminToken})
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, badResp.HTTPResponse.StatusCode, "Bulk delete without a filter should be rejected")
+
+	deleteResp, err := client.DeleteBannerWithResponse(ctx, &generated.DeleteBannerParams{FeatureId: ptrToInt(340), Token: &adminToken})
+	require.NoError(t, err)
+	require.Equal(t, http.StatusAccepted, deleteResp.HTTPResponse.StatusCode)
+	jobID := *deleteResp.JSON202.JobId
+
+	var job *generated.GetJobsIdResponse
+	require.Eventually(t, func() bool {
+		job, err = client.GetJobsIdWithResponse(ctx, jobID, &generated.GetJobsIdParams{Token: &adminToken})
+		return err == nil && job.JSON200 != nil && (*job.JSON200.Status == "done" || *job.JSON200.Status == "failed")
+	}, 15*time.Second, 200*time.Millisecond, "Bulk delete job did not finish")
+	assert.Equal(t, "done", *job.JSON200.Status)
+	assert.Equal(t, 3, *job.JSON200.Deleted)
+
+	getResp, err := client.GetBannerWithResponse(ctx, &generated.GetBannerParams{FeatureId: ptrToInt(340), Token: &adminToken})
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, getResp.HTTPResponse.StatusCode)
+	assert.Empty(t, *getResp.JSON200)
+
+	userResp, err = client.GetUserBannerWithResponse(ctx, &generated.GetUserBannerParams{FeatureId: 340, TagId: 341, Token: &adminToken})
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusNotFound, userResp.HTTPResponse.StatusCode, "Deleted banner should not be served from cache")
+
+	for _, bannerID := range bannerIDs {
+		bannerID := bannerID
+		auditResp, err := client.GetAuditWithResponse(ctx, &generated.GetAuditParams{Token: &adminToken, BannerId: &bannerID})
+		require.NoError(t, err)
+		require.Equal(t, http.StatusOK, auditResp.HTTPResponse.StatusCode)
+		require.NotEmpty(t, *auditResp.JSON200)
+		latest := (*auditResp.JSON200)[0]
+		assert.Equal(t, "banner.delete", *latest.Action, "Bulk deletes should be audited")
+		assert.Equal(t, "admin", *latest.ActorRole)
+	}
+}
+
+func TestBannerActivationWindow(t *testing.T) {
+	client, err := generated.NewClientWithResponses(getTestUrl())
+	require.NoError(t, err, "Failed to create client")
+
+	ctx := context.Background()
+	adminToken := "admin1"
+	userToken := "user1"
+
+	activeFrom := time.Now().Add(time.Hour)
+	postResp, err := client.PostBannerWithResponse(ctx, &generated.PostBannerParams{Token: &adminToken}, generated.PostBannerJSONRequestBody{
+		Content:    &map[string]interface{}{"title": "Midnight Sale"},
+		FeatureId:  ptrToInt(350),
+		IsActive:   ptrToBool(true),
+		TagIds:     &[]int{351},
+		ActiveFrom: &activeFrom,
+	})
+	require.NoError(t, err, "Failed to create banner")
+	require.Equal(t, http.StatusCreated, postResp.HTTPResponse.StatusCode, "Banner creation failed")
+	bannerID := *postResp.JSON201.BannerId
+
+	params := generated.GetUserBannerParams{FeatureId: 350, TagId: 351, Token: &userToken}
+	userResp, err := client.GetUserBannerWithResponse(ctx, &params)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusNotFound, userResp.HTTPResponse.StatusCode, "Banner should be hidden before its window opens")
+
+	adminResp, err := client.GetUserBannerWithResponse(ctx, &generated.GetUserBannerParams{FeatureId: 350, TagId: 351, Token: &adminToken})
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, adminResp.HTTPResponse.StatusCode, "Admin should see scheduled banners")
+
+	getResp, err := client.GetBannerWithResponse(ctx, &generated.GetBannerParams{FeatureId: ptrToInt(350), Token: &adminToken})
+	require.NoError(t, err)
+	require.Len(t, *getResp.JSON200, 1)
+	assert.WithinDuration(t, activeFrom, *(*getResp.JSON200)[0].ActiveFrom, time.Second)
+
+	patchResp, err := client.PatchBannerIdWithBodyWithResponse(ctx, bannerID, &generated.PatchBannerIdParams{Token: &adminToken}, "application/json",
+		strings.NewReader(`{"active_from": null}`))
+	require.NoError(t, err, "Error while patching banner")
+	require.Equal(t, http.StatusOK, patchResp.HTTPResponse.StatusCode, "Banner patching failed")
+
+	userResp, err = client.GetUserBannerWithResponse(ctx, &params)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, userResp.HTTPResponse.StatusCode, "Banner should be visible once the window is cleared")
+
+	activeUntil := time.Now().Add(-time.Minute)
+	patchResp, err = client.PatchBannerIdWithResponse(ctx, bannerID, &generated.PatchBannerIdParams{Token: &adminToken}, generated.PatchBannerIdJSONRequestBody{
+		ActiveUntil: &activeUntil,
+	})
+	require.NoError(t, err, "Error while patching banner")
+	require.Equal(t, http.StatusOK, patchResp.HTTPResponse.StatusCode, "Banner patching failed")
+
+	userResp, err = client.GetUserBannerWithResponse(ctx, &params)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusNotFound, userResp.HTTPResponse.StatusCode, "Banner should be hidden after its window closes")
+
+	invalidFrom := time.Now()
+	badResp, err := client.PatchBannerIdWithResponse(ctx, bannerID, &generated.PatchBannerIdParams{Token: &adminToken}, generated.PatchBannerIdJSONRequestBody{
+		ActiveFrom: &invalidFrom,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, badResp.HTTPResponse.StatusCode, "Window ending before it starts should be rejected")
+}
+
+func TestGetBannerReturnsAllTags(t *testing.T) {
+	client, err := generated.NewClientWithResponses(getTestUrl())
+	require.NoError(t, err, "Failed to create client")
+
+	ctx := context.Background()
+	adminToken := "admin1"
+
+	postResp, err := client.PostBannerWithResponse(ctx, &generated.PostBannerParams{Token: &adminToken}, generated.PostBannerJSONRequestBody{
+		Content:   &map[string]interface{}{"title": "Tagged Banner"},
+		FeatureId: ptrToInt(360),
+		IsActive:  ptrToBool(true),
+		TagIds:    &[]int{361, 362, 363},
+	})
+	require.NoError(t, err, "Failed to create banner")
+	require.Equal(t, http.StatusCreated, postResp.HTTPResponse.StatusCode, "Banner creation failed")
+
+	getResp, err := client.GetBannerWithResponse(ctx, &generated.GetBannerParams{TagId: ptrToInt(362), Token: &adminToken})
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, getResp.HTTPResponse.StatusCode)
+
+	var found bool
+	for _, banner := range *getResp.JSON200 {
+		if *banner.BannerId != *postResp.JSON201.BannerId {
+			continue
+		}
+		found = true
+		assert.Equal(t, 360, *banner.FeatureId)
+		assert.ElementsMatch(t, []int{361, 362, 363}, *banner.TagIds, "Filtering by one tag should still return every tag of the banner")
+	}
+	assert.True(t, found, "Banner should match its tag filter")
+}
+
+func TestGetBannerCursorPagination(t *testing.T) {
+	client, err := generated.NewClientWithResponses(getTestUrl())
+	require.NoError(t, err, "Failed to create client")
+
+	ctx := context.Background()
+	adminToken := "admin1"
+
+	var created []int
+	for _, tagID := range []int{371, 372, 373} {
+		postResp, err := client.PostBannerWithResponse(ctx, &generated.PostBannerParams{Token: &adminToken}, generated.PostBannerJSONRequestBody{
+			Content:   &map[string]interface{}{"title": "Paged Banner"},
+			FeatureId: ptrToInt(370),
+			IsActive:  ptrToBool(true),
+			TagIds:    &[]int{tagID},
+		})
+		require.NoError(t, err, "Failed to create banner")
+		require.Equal(t, http.StatusCreated, postResp.HTTPResponse.StatusCode, "Banner creation failed")
+		created = append(created, *postResp.JSON201.BannerId)
+	}
+
+	var seen []int
+	params := generated.GetBannerParams{FeatureId: ptrToInt(370), Limit: ptrToInt(2), Token: &adminToken}
+	for page := 0; page < len(created); page++ {
+		getResp, err := client.GetBannerWithResponse(ctx, &params)
+		require.NoError(t, err)
+		require.Equal(t, http.StatusOK, getResp.HTTPResponse.StatusCode)
+		for _, banner := range *getResp.JSON200 {
+			seen = append(seen, *banner.BannerId)
+		}
+
+		next := getResp.HTTPResponse.Header.Get("X-Next-Cursor")
+		if next == "" {
+			break
+		}
+		params.Cursor = &next
+	}
+	assert.Equal(t, created, seen, "Pages should cover every banner exactly once in id order")
+
+	badResp, err := client.GetBannerWithResponse(ctx, &generated.GetBannerParams{Cursor: ptrToString("not a cursor"), Token: &adminToken})
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, badResp.HTTPResponse.StatusCode, "Malformed cursor should be rejected")
+
+	offsetResp, err := client.GetBannerWithResponse(ctx, &generated.GetBannerParams{FeatureId: ptrToInt(370), Limit: ptrToInt(2), Offset: ptrToInt(1), Token: &adminToken})
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, offsetResp.HTTPResponse.StatusCode, "Offset pagination should keep working")
+	require.Len(t, *offsetResp.JSON200, 2)
+	assert.Equal(t, created[1], *(*offsetResp.JSON200)[0].BannerId)
+}
+
+func TestFeatureSchemaValidation(t *testing.T) {
+	client, err := generated.NewClientWithResponses(getTestUrl())
+	require.NoError(t, err, "Failed to create client")
+
+	ctx := context.Background()
+	adminToken := "admin1"
+
+	schemaResp, err := client.PutFeatureIdSchemaWithResponse(ctx, 380, &generated.PutFeatureIdSchemaParams{Token: &adminToken}, generated.PutFeatureIdSchemaJSONRequestBody{
+		"type":     "object",
+		"required": []string{"title", "url"},
+		"properties": map[string]interface{}{
+			"title": map[string]interface{}{"type": "string"},
+			"url":   map[string]interface{}{"type": "string"},
+		},
+	})
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, schemaResp.HTTPResponse.StatusCode, "Schema registration failed")
+
+	badResp, err := client.PostBannerWithResponse(ctx, &generated.PostBannerParams{Token: &adminToken}, generated.PostBannerJSONRequestBody{
+		Content:   &map[string]interface{}{"title": 42},
+		FeatureId: ptrToInt(380),
+		IsActive:  ptrToBool(true),
+		TagIds:    &[]int{381},
+	})
+	require.NoError(t, err)
+	require.Equal(t, http.StatusBadRequest, badResp.HTTPResponse.StatusCode, "Content violating the schema should be rejected")
+	assert.Contains(t, string(badResp.Body), `"/title"`, "Violated paths should be reported")
+
+	postResp, err := client.PostBannerWithResponse(ctx, &generated.PostBannerParams{Token: &adminToken}, generated.PostBannerJSONRequestBody{
+		Content:   &map[string]interface{}{"title": "Valid Banner", "url": "https://example.com"},
+		FeatureId: ptrToInt(380),
+		IsActive:  ptrToBool(true),
+		TagIds:    &[]int{381},
+	})
+	require.NoError(t, err)
+	require.Equal(t, http.StatusCreated, postResp.HTTPResponse.StatusCode, "Valid content should be accepted")
+
+	patchResp, err := client.PatchBannerIdWithResponse(ctx, *postResp.JSON201.BannerId, &generated.PatchBannerIdParams{Token: &adminToken}, generated.PatchBannerIdJSONRequestBody{
+		Content: &map[string]interface{}{"url": "https://example.com"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, patchResp.HTTPResponse.StatusCode, "Patch dropping a required field should be rejected")
+
+	invalidResp, err := client.PutFeatureIdSchemaWithResponse(ctx, 380, &generated.PutFeatureIdSchemaParams{Token: &adminToken}, generated.PutFeatureIdSchemaJSONRequestBody{
+		"type": "not-a-type",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, invalidResp.HTTPResponse.StatusCode, "Invalid schema should be rejected")
+
+	deleteResp, err := client.DeleteFeatureIdSchemaWithResponse(ctx, 380, &generated.DeleteFeatureIdSchemaParams{Token: &adminToken})
+	require.NoError(t, err)
+	require.Equal(t, http.StatusNoContent, deleteResp.HTTPResponse.StatusCode, "Schema deletion failed")
+
+	patchResp, err = client.PatchBannerIdWithResponse(ctx, *postResp.JSON201.BannerId, &generated.PatchBannerIdParams{Token: &adminToken}, generated.PatchBannerIdJSONRequestBody{
+		Content: &map[string]interface{}{"anything": true},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, patchResp.HTTPResponse.StatusCode, "Features without a schema should accept any content")
+}
+
+func TestHealthEndpoints(t *testing.T) {
+	resp, err := http.Get(getTestUrl() + "/healthz")
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode, "Liveness probe should succeed")
+
+	resp, err = http.Get(getTestUrl() + "/readyz")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode, "Readiness probe should succeed")
+
+	var readiness struct {
+		Status string `json:"status"`
+		Checks map[string]struct {
+			Status string `json:"status"`
+		} `json:"checks"`
+	}
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&readiness))
+	assert.Equal(t, "up", readiness.Checks["postgres"].Status)
+	assert.Equal(t, "up", readiness.Checks["migrations"].Status)
+	assert.Contains(t, readiness.Checks, "redis")
+}
+
+func TestMetricsEndpoint(t *testing.T) {
+	client, err := generated.NewClientWithResponses(getTestUrl())
+	require.NoError(t, err, "Failed to create client")
+
+	userToken := "user1"
+	_, err = client.GetUserBannerWithResponse(context.Background(), &generated.GetUserBannerParams{FeatureId: 390, TagId: 391, Token: &userToken})
+	require.NoError(t, err)
+
+	resp, err := http.Get(getTestUrl() + "/metrics")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	assert.Contains(t, string(body), `banner_service_http_requests_total{method="GET",route="/user_banner",status="404"}`)
+	assert.Contains(t, string(body), "banner_service_db_query_duration_seconds")
+	assert.Contains(t, string(body), "go_sql_open_connections")
+}
+
+func TestDeleteBannerFreesFeatureTagPair(t *testing.T) {
+	client, err := generated.NewClientWithResponses(getTestUrl())
+	require.NoError(t, err, "Failed to create client")
+
+	ctx := context.Background()
+	adminToken := "admin1"
+	userToken := "user1"
+
+	body := generated.PostBannerJSONRequestBody{
+		Content:   &map[string]interface{}{"title": "Before Delete"},
+		FeatureId: ptrToInt(400),
+		IsActive:  ptrToBool(true),
+		TagIds:    &[]int{401, 402},
+	}
+	postResp, err := client.PostBannerWithResponse(ctx, &generated.PostBannerParams{Token: &adminToken}, body)
+	require.NoError(t, err, "Failed to create banner")
+	require.Equal(t, http.StatusCreated, postResp.HTTPResponse.StatusCode, "Banner creation failed")
+	bannerID := *postResp.JSON201.BannerId
+
+	params := generated.GetUserBannerParams{FeatureId: 400, TagId: 401, Token: &userToken}
+	userResp, err := client.GetUserBannerWithResponse(ctx, &params)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, userResp.HTTPResponse.StatusCode)
+
+	deleteResp, err := client.DeleteBannerIdWithResponse(ctx, bannerID, &generated.DeleteBannerIdParams{Token: &adminToken})
+	require.NoError(t, err)
+	require.Equal(t, http.StatusNoContent, deleteResp.HTTPResponse.StatusCode)
+
+	deleteResp, err = client.DeleteBannerIdWithResponse(ctx, bannerID, &generated.DeleteBannerIdParams{Token: &adminToken})
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusNotFound, deleteResp.HTTPResponse.StatusCode, "Deleting a missing banner should return 404")
+
+	userResp, err = client.GetUserBannerWithResponse(ctx, &params)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusNotFound, userResp.HTTPResponse.StatusCode, "Deleted banner should not be served from cache")
+
+	body.Content = &map[string]interface{}{"title": "After Delete"}
+	postResp, err = client.PostBannerWithResponse(ctx, &generated.PostBannerParams{Token: &adminToken}, body)
+	require.NoError(t, err, "Failed to recreate banner")
+	require.Equal(t, http.StatusCreated, postResp.HTTPResponse.StatusCode, "Feature/tag pairs of a deleted banner should be free")
+
+	userResp, err = client.GetUserBannerWithResponse(ctx, &params)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, userResp.HTTPResponse.StatusCode)
+	assert.Equal(t, &map[string]interface{}{"title": "After Delete"}, userResp.JSON200)
+}
+
+func TestBannerTrashAndRestore(t *testing.T) {
+	client, err := generated.NewClientWithResponses(getTestUrl())
+	require.NoError(t, err, "Failed to create client")
+
+	ctx := context.Background()
+	adminToken := "admin1"
+	userToken := "user1"
+
+	body := generated.PostBannerJSONRequestBody{
+		Content:   &map[string]interface{}{"title": "Trashed"},
+		FeatureId: ptrToInt(410),
+		IsActive:  ptrToBool(true),
+		TagIds:    &[]int{411, 412},
+	}
+	postResp, err := client.PostBannerWithResponse(ctx, &generated.PostBannerParams{Token: &adminToken}, body)
+	require.NoError(t, err, "Failed to create banner")
+	require.Equal(t, http.StatusCreated, postResp.HTTPResponse.StatusCode, "Banner creation failed")
+	bannerID := *postResp.JSON201.BannerId
+
+	deleteResp, err := client.DeleteBannerIdWithResponse(ctx, bannerID, &generated.DeleteBannerIdParams{Token: &adminToken})
+	require.NoError(t, err)
+	require.Equal(t, http.StatusNoContent, deleteResp.HTTPResponse.StatusCode)
+
+	listResp, err := client.GetBannerWithResponse(ctx, &generated.GetBannerParams{Token: &adminToken, FeatureId: ptrToInt(410)})
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, listResp.HTTPResponse.StatusCode)
+	assert.Empty(t, *listResp.JSON200, "Deleted banner should not be listed by default")
+
+	trashResp, err := client.GetBannerWithResponse(ctx, &generated.GetBannerParams{Token: &adminToken, FeatureId: ptrToInt(410), Deleted: ptrToBool(true)})
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, trashResp.HTTPResponse.StatusCode)
+	require.Len(t, *trashResp.JSON200, 1)
+	trashed := (*trashResp.JSON200)[0]
+	assert.Equal(t, bannerID, *trashed.BannerId)
+	assert.ElementsMatch(t, []int{411, 412}, *trashed.TagIds)
+	assert.NotNil(t, trashed.DeletedAt)
+
+	params := generated.GetUserBannerParams{FeatureId: 410, TagId: 411, Token: &userToken}
+	userResp, err := client.GetUserBannerWithResponse(ctx, &params)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusNotFound, userResp.HTTPResponse.StatusCode)
+
+	restoreResp, err := client.PostBannerIdRestoreWithResponse(ctx, bannerID, &generated.PostBannerIdRestoreParams{Token: &adminToken})
+	require.NoError(t, err)
+	require.Equal(t, http.StatusNoContent, restoreResp.HTTPResponse.StatusCode)
+
+	userResp, err = client.GetUserBannerWithResponse(ctx, &params)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, userResp.HTTPResponse.StatusCode)
+	assert.Equal(t, &map[string]interface{}{"title": "Trashed"}, userResp.JSON200)
+
+	restoreResp, err = client.PostBannerIdRestoreWithResponse(ctx, bannerID, &generated.PostBannerIdRestoreParams{Token: &adminToken})
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusNotFound, restoreResp.HTTPResponse.StatusCode, "Only deleted banners can be restored")
+
+	deleteResp, err = client.DeleteBannerIdWithResponse(ctx, bannerID, &generated.DeleteBannerIdParams{Token: &adminToken})
+	require.NoError(t, err)
+	require.Equal(t, http.StatusNoContent, deleteResp.HTTPResponse.StatusCode)
+
+	body.TagIds = &[]int{412}
+	postResp, err = client.PostBannerWithResponse(ctx, &generated.PostBannerParams{Token: &adminToken}, body)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusCreated, postResp.HTTPResponse.StatusCode)
+
+	restoreResp, err = client.PostBannerIdRestoreWithResponse(ctx, bannerID, &generated.PostBannerIdRestoreParams{Token: &adminToken})
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusConflict, restoreResp.HTTPResponse.StatusCode, "Restore should fail when a pair is taken")
+}
+
+func TestRestoreSeededBanner(t *testing.T) {
+	client, err := generated.NewClientWithResponses(getTestUrl())
+	require.NoError(t, err, "Failed to create client")
+
+	ctx := context.Background()
+	adminToken := "admin1"
+	userToken := "user1"
+
+	// The Winter Sale banner from init.sql predates banner versions.
+	bannerID := 2
+	params := generated.GetUserBannerParams{FeatureId: 2, TagId: 201, Token: &userToken}
+	userResp, err := client.GetUserBannerWithResponse(ctx, &params)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, userResp.HTTPResponse.StatusCode)
+	seeded := userResp.JSON200
+
+	deleteResp, err := client.DeleteBannerIdWithResponse(ctx, bannerID, &generated.DeleteBannerIdParams{Token: &adminToken})
+	require.NoError(t, err)
+	require.Equal(t, http.StatusNoContent, deleteResp.HTTPResponse.StatusCode)
+
+	trashResp, err := client.GetBannerWithResponse(ctx, &generated.GetBannerParams{Token: &adminToken, FeatureId: ptrToInt(2), Deleted: ptrToBool(true)})
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, trashResp.HTTPResponse.StatusCode)
+	require.Len(t, *trashResp.JSON200, 1)
+	trashed := (*trashResp.JSON200)[0]
+	assert.Equal(t, bannerID, *trashed.BannerId)
+	assert.Equal(t, 2, *trashed.FeatureId)
+	assert.ElementsMatch(t, []int{201, 202}, *trashed.TagIds)
+
+	versionsResp, err := client.GetBannerVersionsIdWithResponse(ctx, bannerID, &generated.GetBannerVersionsIdParams{Token: &adminToken})
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, versionsResp.HTTPResponse.StatusCode, "Versions of a deleted banner should stay visible")
+	require.NotEmpty(t, *versionsResp.JSON200)
+	assert.ElementsMatch(t, []int{201, 202}, *(*versionsResp.JSON200)[0].TagIds)
+
+	restoreResp, err := client.PostBannerIdRestoreWithResponse(ctx, bannerID, &generated.PostBannerIdRestoreParams{Token: &adminToken})
+	require.NoError(t, err)
+	require.Equal(t, http.StatusNoContent, restoreResp.HTTPResponse.StatusCode)
+
+	userResp, err = client.GetUserBannerWithResponse(ctx, &params)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, userResp.HTTPResponse.StatusCode)
+	assert.Equal(t, seeded, userResp.JSON200)
+}
+
+func TestAuditLog(t *testing.T) {
+	client, err := generated.NewClientWithResponses(getTestUrl())
+	require.NoError(t, err, "Failed to create client")
+
+	ctx := context.Background()
+	adminToken := "admin1"
+
+	postResp, err := client.PostBannerWithResponse(ctx, &generated.PostBannerParams{Token: &adminToken}, generated.PostBannerJSONRequestBody{
+		Content:   &map[string]interface{}{"title": "Audited"},
+		FeatureId: ptrToInt(420),
+		IsActive:  ptrToBool(true),
+		TagIds:    &[]int{421},
+	})
+	require.NoError(t, err, "Failed to create banner")
+	require.Equal(t, http.StatusCreated, postResp.HTTPResponse.StatusCode, "Banner creation failed")
+	bannerID := *postResp.JSON201.BannerId
+
+	patchResp, err := client.PatchBannerIdWithResponse(ctx, bannerID, &generated.PatchBannerIdParams{Token: &adminToken}, generated.PatchBannerIdJSONRequestBody{
+		Content: &map[string]interface{}{"title": "Audited again"},
+	})
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, patchResp.HTTPResponse.StatusCode)
+
+	deleteResp, err := client.DeleteBannerIdWithResponse(ctx, bannerID, &generated.DeleteBannerIdParams{Token: &adminToken})
+	require.NoError(t, err)
+	require.Equal(t, http.StatusNoContent, deleteResp.HTTPResponse.StatusCode)
+
+	auditResp, err := client.GetAuditWithResponse(ctx, &generated.GetAuditParams{Token: &adminToken, BannerId: &bannerID})
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, auditResp.HTTPResponse.StatusCode)
+	events := *auditResp.JSON200
+	require.Len(t, events, 3)
+	assert.Equal(t, "banner.delete", *events[0].Action)
+	assert.Equal(t, "banner.update", *events[1].Action)
+	assert.Equal(t, "banner.create", *events[2].Action)
+	for _, event := range events {
+		assert.Equal(t, bannerID, *event.BannerId)
+		assert.Equal(t, "admin", *event.ActorRole)
+		assert.NotEmpty(t, *event.ActorTokenId)
+	}
+	assert.Equal(t, map[string]interface{}{
+		"content": map[string]interface{}{
+			"before": map[string]interface{}{"title": "Audited"},
+			"after":  map[string]interface{}{"title": "Audited again"},
+		},
+	}, *events[1].Diff, "Update should only record the changed content")
+
+	actorResp, err := client.GetAuditWithResponse(ctx, &generated.GetAuditParams{Token: &adminToken, BannerId: &bannerID, Actor: events[0].ActorTokenId})
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, actorResp.HTTPResponse.StatusCode)
+	assert.Len(t, *actorResp.JSON200, 3)
+
+	from := events[0].CreatedAt.Add(time.Second)
+	rangeResp, err := client.GetAuditWithResponse(ctx, &generated.GetAuditParams{Token: &adminToken, BannerId: &bannerID, From: &from})
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, rangeResp.HTTPResponse.StatusCode)
+	assert.Empty(t, *rangeResp.JSON200)
+
+	userToken := "user1"
+	forbiddenResp, err := client.GetAuditWithResponse(ctx, &generated.GetAuditParams{Token: &userToken})
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusForbidden, forbiddenResp.HTTPResponse.StatusCode)
+}
+
+func TestUserBannerRateLimit(t *testing.T) {
+	client, err := generated.NewClientWithResponses(getTestUrl())
+	require.NoError(t, err, "Failed to create client")
+
+	ctx := context.Background()
+	adminToken := "admin1"
+
+	postResp, err := client.PostBannerWithResponse(ctx, &generated.PostBannerParams{Token: &adminToken}, generated.PostBannerJSONRequestBody{
+		Content:   &map[string]interface{}{"title": "Limited"},
+		FeatureId: ptrToInt(430),
+		IsActive:  ptrToBool(true),
+		TagIds:    &[]int{431},
+	})
+	require.NoError(t, err, "Failed to create banner")
+	require.Equal(t, http.StatusCreated, postResp.HTTPResponse.StatusCode, "Banner creation failed")
+
+	// A fresh token starts with a full bucket of its own.
+	role := generated.User
+	mintResp, err := client.PostTokensWithResponse(ctx, &generated.PostTokensParams{Token: &adminToken}, generated.PostTokensJSONRequestBody{
+		Role:       &role,
+		TtlSeconds: ptrToInt(3600),
+	})
+	require.NoError(t, err, "Failed to mint token")
+	require.Equal(t, http.StatusCreated, mintResp.HTTPResponse.StatusCode)
+	userToken := *mintResp.JSON201.Token
+
+	params := generated.GetUserBannerParams{FeatureId: 430, TagId: 431, Token: &userToken}
+	var limited *generated.GetUserBannerResponse
+	for i := 0; i < 1000; i++ {
+		userResp, err := client.GetUserBannerWithResponse(ctx, &params)
+		require.NoError(t, err)
+		if userResp.HTTPResponse.StatusCode == http.StatusTooManyRequests {
+			limited = userResp
+			break
+		}
+		require.Equal(t, http.StatusOK, userResp.HTTPResponse.StatusCode)
+		assert.NotEmpty(t, userResp.HTTPResponse.Header.Get("X-RateLimit-Limit"))
+	}
+	require.NotNil(t, limited, "Token should be rate limited")
+	assert.NotEmpty(t, limited.HTTPResponse.Header.Get("Retry-After"))
+	assert.Equal(t, "0", limited.HTTPResponse.Header.Get("X-RateLimit-Remaining"))
+
+	// Other tokens are not affected.
+	userToken = "user1"
+	userResp, err := client.GetUserBannerWithResponse(ctx, &params)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, userResp.HTTPResponse.StatusCode)
+}
+
+func TestBannerExportAndImport(t *testing.T) {
+	client, err := generated.NewClientWithResponses(getTestUrl())
+	require.NoError(t, err, "Failed to create client")
+
+	ctx := context.Background()
+	adminToken := "admin1"
+
+	postResp, err := client.PostBannerWithResponse(ctx, &generated.PostBannerParams{Token: &adminToken}, generated.PostBannerJSONRequestBody{
+		Content:   &map[string]interface{}{"title": "Exported"},
+		FeatureId: ptrToInt(440),
+		IsActive:  ptrToBool(true),
+		TagIds:    &[]int{441},
+	})
+	require.NoError(t, err, "Failed to create banner")
+	require.Equal(t, http.StatusCreated, postResp.HTTPResponse.StatusCode, "Banner creation failed")
+	bannerID := *postResp.JSON201.BannerId
+
+	exportResp, err := client.GetBannerExportWithResponse(ctx, &generated.GetBannerExportParams{Token: &adminToken})
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, exportResp.HTTPResponse.StatusCode)
+	assert.Equal(t, "application/x-ndjson", exportResp.HTTPResponse.Header.Get("Content-Type"))
+	var exported string
+	for _, line := range strings.Split(string(exportResp.Body), "\n") {
+		var record map[string]interface{}
+		if line == "" || json.Unmarshal([]byte(line), &record) != nil {
+			continue
+		}
+		if record["banner_id"] == float64(bannerID) {
+			exported = line
+		}
+	}
+	require.NotEmpty(t, exported, "Banner should be exported")
+	assert.Contains(t, exported, `"tag_ids":[441]`)
+
+	importBanners := func(mode generated.PostBannerImportParamsMode, format generated.PostBannerImportParamsFormat, body string) *generated.PostBannerImportResponse {
+		resp, err := client.PostBannerImportWithBodyWithResponse(ctx, &generated.PostBannerImportParams{Token: &adminToken, Mode: &mode, Format: &format}, "text/plain", strings.NewReader(body))
+		require.NoError(t, err)
+		return resp
+	}
+
+	// Re-importing the banner conflicts with itself.
+	conflictResp := importBanners(generated.Fail, generated.PostBannerImportParamsFormatNdjson, exported+"\n")
+	require.Equal(t, http.StatusConflict, conflictResp.HTTPResponse.StatusCode)
+	require.Len(t, *conflictResp.JSON409.Lines, 1)
+	assert.Equal(t, "conflict", *(*conflictResp.JSON409.Lines)[0].Status)
+	assert.Equal(t, bannerID, *(*conflictResp.JSON409.Lines)[0].BannerId)
+
+	skipResp := importBanners(generated.Skip, generated.PostBannerImportParamsFormatNdjson, exported+"\n")
+	require.Equal(t, http.StatusOK, skipResp.HTTPResponse.StatusCode)
+	assert.Equal(t, 1, *skipResp.JSON200.Skipped)
+
+	updated := strings.Replace(exported, `"Exported"`, `"Imported"`, 1)
+	upsertResp := importBanners(generated.Upsert, generated.PostBannerImportParamsFormatNdjson, updated+"\n")
+	require.Equal(t, http.StatusOK, upsertResp.HTTPResponse.StatusCode)
+	assert.Equal(t, 1, *upsertResp.JSON200.Updated)
+	assert.Equal(t, bannerID, *(*upsertResp.JSON200.Lines)[0].BannerId)
+
+	csv := "feature_id,tag_ids,is_active,content\n440,[442],true,\"{\"\"title\"\":\"\"From CSV\"\"}\"\n"
+	createResp := importBanners(generated.Fail, generated.PostBannerImportParamsFormatCsv, csv)
+	require.Equal(t, http.StatusOK, createResp.HTTPResponse.StatusCode)
+	assert.Equal(t, 1, *createResp.JSON200.Created)
+	assert.Equal(t, 2, *(*createResp.JSON200.Lines)[0].Line)
+
+	// An invalid line rolls back the lines before it.
+	invalidResp := importBanners(generated.Fail, generated.PostBannerImportParamsFormatNdjson,
+		`{"feature_id":440,"tag_ids":[443],"is_active":true,"content":{"title":"Rolled back"}}`+"\n"+`{"feature_id":440,"tag_ids":[444]}`+"\n")
+	require.Equal(t, http.StatusBadRequest, invalidResp.HTTPResponse.StatusCode)
+	lines := *invalidResp.JSON400.Lines
+	require.Len(t, lines, 2)
+	assert.Equal(t, "created", *lines[0].Status)
+	assert.Equal(t, "invalid", *lines[1].Status)
+
+	listResp, err := client.GetBannerWithResponse(ctx, &generated.GetBannerParams{Token: &adminToken, FeatureId: ptrToInt(440)})
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, listResp.HTTPResponse.StatusCode)
+	require.Len(t, *listResp.JSON200, 2)
+	contents := map[int]string{}
+	for _, banner := range *listResp.JSON200 {
+		contents[(*banner.TagIds)[0]] = (*banner.Content)["title"].(string)
+	}
+	assert.Equal(t, map[int]string{441: "Imported", 442: "From CSV"}, contents)
+}
+
+func TestUserBannerBatch(t *testing.T) {
+	client, err := generated.NewClientWithResponses(getTestUrl())
+	require.NoError(t, err, "Failed to create client")
+
+	ctx := context.Background()
+	adminToken := "admin1"
+	userToken := "user1"
+
+	for featureID, isActive := range map[int]bool{450: true, 451: true, 452: false} {
+		postResp, err := client.PostBannerWithResponse(ctx, &generated.PostBannerParams{Token: &adminToken}, generated.PostBannerJSONRequestBody{
+			Content:   &map[string]interface{}{"title": fmt.Sprintf("Feature %d", featureID)},
+			FeatureId: ptrToInt(featureID),
+			IsActive:  ptrToBool(isActive),
+			TagIds:    &[]int{459},
+		})
+		require.NoError(t, err, "Failed to create banner")
+		require.Equal(t, http.StatusCreated, postResp.HTTPResponse.StatusCode, "Banner creation failed")
+	}
+
+	body := generated.PostUserBannerBatchJSONRequestBody{TagId: 459, FeatureIds: []int{450, 451, 452, 453, 450}}
+	// The second call is answered from the cache filled by the first one.
+	for i := 0; i < 2; i++ {
+		batchResp, err := client.PostUserBannerBatchWithResponse(ctx, &generated.PostUserBannerBatchParams{Token: &userToken}, body)
+		require.NoError(t, err)
+		require.Equal(t, http.StatusOK, batchResp.HTTPResponse.StatusCode)
+		assert.Equal(t, map[string]map[string]interface{}{
+			"450": {"title": "Feature 450"},
+			"451": {"title": "Feature 451"},
+		}, *batchResp.JSON200, "Missing and inactive banners should be omitted")
+	}
+
+	adminResp, err := client.PostUserBannerBatchWithResponse(ctx, &generated.PostUserBannerBatchParams{Token: &adminToken}, body)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, adminResp.HTTPResponse.StatusCode)
+	assert.Len(t, *adminResp.JSON200, 3, "Admins should see inactive banners")
+
+	emptyResp, err := client.PostUserBannerBatchWithResponse(ctx, &generated.PostUserBannerBatchParams{Token: &userToken}, generated.PostUserBannerBatchJSONRequestBody{TagId: 459})
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, emptyResp.HTTPResponse.StatusCode)
+}
+
 func ptrToInt(i int) *int {
 	return &i
 }
@@ -203,3 +1098,7 @@ func ptrToInt(i int) *int {
 func ptrToBool(b bool) *bool {
 	return &b
 }
+
+func ptrToString(s string) *string {
+	return &s
+}